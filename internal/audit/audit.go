@@ -0,0 +1,257 @@
+// Package audit keeps an append-only record of every change simili makes to
+// issues: transfers, closes, labels, comments and the lifecycle of pending
+// actions.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+// DefaultPath is where the audit log is written
+const DefaultPath = ".simili/audit.jsonl"
+
+// SystemActor is the actor of changes nobody asked for explicitly
+const SystemActor = "simili"
+
+// Outcomes of an audited change
+const (
+	OutcomeScheduled = "scheduled"
+	OutcomeDecided   = "decided"
+	OutcomeExecuted  = "executed"
+	OutcomeCancelled = "cancelled"
+	OutcomeReverted  = "reverted"
+	OutcomeFailed    = "failed"
+)
+
+// Entry is one audited change
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Org        string    `json:"org"`
+	Repo       string    `json:"repo"`
+	Issue      int       `json:"issue"`
+	Action     string    `json:"action"`           // e.g. "transfer", "close_duplicate", "add_label" or "pending_transfer"
+	Target     string    `json:"target,omitempty"` // target repository, label, assignee or original issue
+	Actor      string    `json:"actor"`            // users who asked for the change, or "simili"
+	Rule       string    `json:"rule,omitempty"`   // rule or reason that triggered the change
+	Confidence float64   `json:"confidence,omitempty"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Log appends entries to a JSON Lines file. A nil *Log records nothing, so
+// callers do not need to check whether auditing is enabled.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New returns the audit log configured in cfg, or nil when auditing is
+// disabled or there is no config
+func New(cfg *config.Config) *Log {
+	if cfg == nil || !cfg.Audit.Enabled {
+		return nil
+	}
+	path := cfg.Audit.Path
+	if path == "" {
+		path = DefaultPath
+	}
+	return &Log{path: path}
+}
+
+// Record appends an entry, filling in the time and actor when missing.
+// Failures are logged rather than returned so auditing never blocks the
+// change it describes.
+func (l *Log) Record(e Entry) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Actor == "" {
+		e.Actor = SystemActor
+	}
+	if err := l.append(e); err != nil {
+		log.Printf("Warning: failed to write audit log: %v", err)
+	}
+}
+
+// append writes one line; O_APPEND keeps concurrent writers from
+// interleaving whole lines
+func (l *Log) append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+// Actor formats the users behind a change, defaulting to SystemActor
+func Actor(users []string) string {
+	if len(users) == 0 {
+		return SystemActor
+	}
+	return strings.Join(users, ",")
+}
+
+// OutcomeOf returns OutcomeFailed for an error and success otherwise
+func OutcomeOf(err error, success string) string {
+	if err != nil {
+		return OutcomeFailed
+	}
+	return success
+}
+
+// ErrorText returns the error message, or "" for nil
+func ErrorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// Filter selects audit entries; zero fields match everything
+type Filter struct {
+	Org     string
+	Repo    string
+	Issue   int
+	Action  string
+	Outcome string
+	Actor   string
+	Since   time.Time
+}
+
+// Match reports whether an entry passes the filter
+func (f Filter) Match(e Entry) bool {
+	if f.Org != "" && !strings.EqualFold(f.Org, e.Org) {
+		return false
+	}
+	if f.Repo != "" && !strings.EqualFold(f.Repo, e.Repo) {
+		return false
+	}
+	if f.Issue != 0 && f.Issue != e.Issue {
+		return false
+	}
+	if f.Action != "" && f.Action != e.Action {
+		return false
+	}
+	if f.Outcome != "" && f.Outcome != e.Outcome {
+		return false
+	}
+	if f.Actor != "" && !containsActor(e.Actor, f.Actor) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	return true
+}
+
+// containsActor reports whether actor is one of the comma-separated users
+func containsActor(actors, actor string) bool {
+	for _, a := range strings.Split(actors, ",") {
+		if strings.EqualFold(a, actor) {
+			return true
+		}
+	}
+	return false
+}
+
+// Read returns the entries of the audit log at path that match the filter,
+// oldest first. A missing file is an empty log.
+func Read(path string, f Filter) ([]Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, fmt.Errorf("audit log line %d: %w", lineNo, err)
+		}
+		if f.Match(e) {
+			entries = append(entries, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// ReadAll reads several audit logs, such as the logs of separate workflow
+// runs, and merges their matching entries oldest first
+func ReadAll(paths []string, f Filter) ([]Entry, error) {
+	var entries []Entry
+	for _, path := range paths {
+		read, err := Read(path, f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		entries = append(entries, read...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// WriteTable writes entries as a plain text table
+func WriteTable(w io.Writer, entries []Entry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No audit entries found")
+		return
+	}
+
+	fmt.Fprintf(w, "%-20s %-30s %-18s %-30s %-20s %-10s %s\n", "time", "issue", "action", "target", "actor", "outcome", "rule")
+	for _, e := range entries {
+		outcome := e.Outcome
+		if e.Error != "" {
+			outcome += ": " + e.Error
+		}
+		fmt.Fprintf(w, "%-20s %-30s %-18s %-30s %-20s %-10s %s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), fmt.Sprintf("%s/%s#%d", e.Org, e.Repo, e.Issue),
+			e.Action, e.Target, e.Actor, outcome, e.Rule)
+	}
+}
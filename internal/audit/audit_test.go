@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestRecordAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	cfg := &config.Config{Audit: config.AuditConfig{Enabled: true, Path: path}}
+	l := New(cfg)
+
+	l.Record(Entry{Org: "org", Repo: "repo", Issue: 1, Action: "transfer", Target: "org/other", Actor: "alice", Outcome: OutcomeExecuted})
+	l.Record(Entry{Org: "org", Repo: "repo", Issue: 2, Action: "close_duplicate", Outcome: OutcomeOf(errors.New("boom"), OutcomeExecuted), Error: "boom"})
+
+	entries, err := Read(path, Filter{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Read() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].Target != "org/other" || entries[0].Time.IsZero() {
+		t.Errorf("first entry = %+v", entries[0])
+	}
+	if entries[1].Actor != SystemActor || entries[1].Outcome != OutcomeFailed {
+		t.Errorf("second entry = %+v, want actor %q and outcome %q", entries[1], SystemActor, OutcomeFailed)
+	}
+
+	missing, err := Read(filepath.Join(t.TempDir(), "missing.jsonl"), Filter{})
+	if err != nil || len(missing) != 0 {
+		t.Errorf("Read() of a missing log = %v, %v; want empty", missing, err)
+	}
+
+	if New(&config.Config{}) != nil {
+		t.Error("New() should return nil when auditing is disabled")
+	}
+	var disabled *Log
+	disabled.Record(Entry{Action: "transfer"})
+}
+
+func TestReadAll(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "run-1.jsonl"), filepath.Join(dir, "run-2.jsonl")
+	start := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	New(&config.Config{Audit: config.AuditConfig{Enabled: true, Path: first}}).Record(Entry{Time: start, Issue: 1, Action: "transfer", Outcome: OutcomeExecuted})
+	New(&config.Config{Audit: config.AuditConfig{Enabled: true, Path: first}}).Record(Entry{Time: start.Add(2 * time.Hour), Issue: 3, Action: "transfer", Outcome: OutcomeExecuted})
+	New(&config.Config{Audit: config.AuditConfig{Enabled: true, Path: second}}).Record(Entry{Time: start.Add(time.Hour), Issue: 2, Action: "transfer", Outcome: OutcomeExecuted})
+
+	entries, err := ReadAll([]string{first, second}, Filter{})
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(entries) != 3 || entries[0].Issue != 1 || entries[1].Issue != 2 || entries[2].Issue != 3 {
+		t.Errorf("ReadAll() = %+v, want issues 1, 2, 3 in time order", entries)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	entry := Entry{Time: now, Org: "Org", Repo: "repo", Issue: 7, Action: "transfer", Actor: "alice,bob", Outcome: OutcomeExecuted}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   bool
+	}{
+		{"empty filter", Filter{}, true},
+		{"repo ignores case", Filter{Org: "org", Repo: "REPO"}, true},
+		{"other repo", Filter{Org: "org", Repo: "other"}, false},
+		{"issue", Filter{Issue: 7}, true},
+		{"other issue", Filter{Issue: 8}, false},
+		{"action", Filter{Action: "revert"}, false},
+		{"outcome", Filter{Outcome: OutcomeExecuted}, true},
+		{"one of the actors", Filter{Actor: "Bob"}, true},
+		{"not an actor", Filter{Actor: "carol"}, false},
+		{"since before", Filter{Since: now.Add(-time.Hour)}, true},
+		{"since after", Filter{Since: now.Add(time.Hour)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(entry); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
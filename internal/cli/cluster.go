@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/cluster"
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+func newClusterCmd() *cobra.Command {
+	var (
+		repo      string
+		threshold float64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Group indexed duplicate issues into clusters",
+		Long: `Group highly similar indexed issues of a repository into duplicate clusters.
+The cluster ID is stored with each issue in the vector database so new
+duplicates are pointed at the cluster's canonical issue.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if threshold > 0 {
+				cfg.Clustering.Threshold = threshold
+			}
+
+			org, repoName, err := github.ParseRepo(repo)
+			if err != nil {
+				return err
+			}
+
+			vdb, err := vectordb.NewClient(&cfg.Qdrant)
+			if err != nil {
+				return fmt.Errorf("failed to create vector DB client: %w", err)
+			}
+			defer vdb.Close()
+
+			manager := cluster.NewManager(vdb, &cfg.Clustering)
+			stats, err := manager.Build(ctx, org, repoName, dryRun)
+			if err != nil {
+				return fmt.Errorf("clustering failed: %w", err)
+			}
+
+			fmt.Printf("Found %d clusters covering %d of %d issues (%d updated)\n",
+				stats.Clusters, stats.Members, stats.Issues, stats.Updated)
+			if dryRun {
+				fmt.Println("Dry run: no cluster IDs were written")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository to cluster (owner/repo)")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0, "minimum similarity to group issues (default: clustering.threshold)")
+	_ = cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/eval"
+	"github.com/spf13/cobra"
+)
+
+func newEvalCmd() *cobra.Command {
+	var (
+		repos     []string
+		from      float64
+		to        float64
+		step      float64
+		negatives int
+		limit     int
+		format    string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "Evaluate similarity and duplicate thresholds against history",
+		Long: `Build a labeled dataset from issues closed as duplicates ("Duplicate of #N"),
+replay each one against the index as it was when the issue was created, and
+report precision, recall, F1 and MRR over a sweep of thresholds.
+
+Scores are raw cosine similarity without the closed issue weight.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			if format != "table" && format != "json" && format != "csv" {
+				return fmt.Errorf("invalid format: %s (expected table, json or csv)", format)
+			}
+
+			thresholds := eval.Thresholds(from, to, step)
+			if len(thresholds) == 0 {
+				return fmt.Errorf("invalid threshold range: --from %.2f --to %.2f --step %.2f", from, to, step)
+			}
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if len(repos) == 0 {
+				for _, r := range cfg.Repositories {
+					if r.Enabled {
+						repos = append(repos, r.Org+"/"+r.Repo)
+					}
+				}
+			}
+			if len(repos) == 0 {
+				return fmt.Errorf("no repositories to evaluate")
+			}
+
+			runner, err := eval.NewRunner(cfg)
+			if err != nil {
+				return fmt.Errorf("failed to create evaluation runner: %w", err)
+			}
+			defer runner.Close()
+
+			opts := eval.Options{
+				Thresholds:   thresholds,
+				MaxNegatives: negatives,
+				Limit:        limit,
+			}
+
+			var reports []*eval.RepoReport
+			for _, repo := range repos {
+				report, err := runner.EvaluateRepo(ctx, repo, opts)
+				if err != nil {
+					return fmt.Errorf("evaluation of %s failed: %w", repo, err)
+				}
+				reports = append(reports, report)
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			switch format {
+			case "json":
+				err = eval.WriteJSON(w, reports)
+			case "csv":
+				err = eval.WriteCSV(w, reports)
+			default:
+				eval.WriteTable(w, reports)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write report: %w", err)
+			}
+
+			if output != "" {
+				fmt.Printf("Report written to: %s\n", output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&repos, "repo", nil, "repository to evaluate (owner/repo, repeatable; default: all enabled repos)")
+	cmd.Flags().Float64Var(&from, "from", 0.70, "lowest threshold in the sweep")
+	cmd.Flags().Float64Var(&to, "to", 0.99, "highest threshold in the sweep")
+	cmd.Flags().Float64Var(&step, "step", 0.01, "threshold step")
+	cmd.Flags().IntVar(&negatives, "negatives", 200, "maximum non-duplicate issues replayed to measure false positives")
+	cmd.Flags().IntVar(&limit, "limit", 10, "search results considered per issue")
+	cmd.Flags().StringVar(&format, "format", "table", "output format: table, json or csv")
+	cmd.Flags().StringVar(&output, "output", "", "path to write the report (default: stdout)")
+
+	return cmd
+}
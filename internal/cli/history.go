@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Kavirubc/gh-simili/internal/audit"
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/spf13/cobra"
+)
+
+func newHistoryCmd() *cobra.Command {
+	var (
+		repo    string
+		since   string
+		action  string
+		outcome string
+		actor   string
+		issue   int
+		format  string
+		output  string
+		logs    []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the audit log of changes made to issues",
+		Long: `Print the entries of the audit log (audit.path) oldest first: transfers,
+reverts, closes, label and comment changes, and the lifecycle of pending
+actions, with who asked for each and what came of it.
+
+The log is only written when audit.enabled is true. Use --log to read other
+files instead, e.g. the logs of several workflow runs downloaded as
+artifacts; they are merged by time.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "table" && format != "json" {
+				return fmt.Errorf("invalid format: %s (expected table or json)", format)
+			}
+
+			paths, err := expandLogs(logs)
+			if err != nil {
+				return err
+			}
+			if len(paths) == 0 {
+				cfgPath := config.FindConfigPath(cfgFile)
+				if cfgPath == "" {
+					return fmt.Errorf("config file not found")
+				}
+
+				cfg, err := config.Load(cfgPath)
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				paths = []string{cfg.Audit.Path}
+			}
+
+			filter := audit.Filter{Issue: issue, Action: action, Outcome: outcome, Actor: actor}
+			if repo != "" {
+				filter.Org, filter.Repo, err = github.ParseRepo(repo)
+				if err != nil {
+					return err
+				}
+			}
+			if since != "" {
+				filter.Since, err = processor.ParseSinceDuration(since)
+				if err != nil {
+					return fmt.Errorf("invalid since duration: %w", err)
+				}
+			}
+
+			entries, err := audit.ReadAll(paths, filter)
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if format == "json" {
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(entries); err != nil {
+					return fmt.Errorf("failed to write history: %w", err)
+				}
+			} else {
+				audit.WriteTable(w, entries)
+			}
+
+			if output != "" {
+				fmt.Printf("History written to: %s\n", output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "only show this repository (owner/repo)")
+	cmd.Flags().StringVar(&since, "since", "", "only show entries since (e.g., 24h, 30d)")
+	cmd.Flags().StringVar(&action, "action", "", "only show this action (e.g., transfer, revert, pending_close)")
+	cmd.Flags().StringVar(&outcome, "outcome", "", "only show this outcome (scheduled, decided, executed, cancelled, reverted, failed)")
+	cmd.Flags().StringVar(&actor, "actor", "", "only show changes requested by this user")
+	cmd.Flags().IntVar(&issue, "issue", 0, "only show this issue number")
+	cmd.Flags().StringVar(&format, "format", "table", "output format: table or json")
+	cmd.Flags().StringVar(&output, "output", "", "path to write the history (default: stdout)")
+	cmd.Flags().StringSliceVar(&logs, "log", nil, "audit log files or glob patterns to read (default: audit.path)")
+
+	return cmd
+}
+
+// expandLogs expands glob patterns in --log values; a pattern that matches
+// nothing is an error so a mistyped path is not read as an empty history
+func expandLogs(patterns []string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no audit log matches %q", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
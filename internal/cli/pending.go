@@ -3,20 +3,20 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/pending"
-	"github.com/Kavirubc/gh-simili/internal/transfer"
+	"github.com/Kavirubc/gh-simili/internal/pipeline"
 	"github.com/Kavirubc/gh-simili/internal/triage"
-	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/spf13/cobra"
 )
 
 func newProcessPendingCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "process-pending",
-		Short: "Process expired pending actions (transfers and closes)",
+		Short: "Process expired pending actions (transfers, closes and triage actions)",
 		Long:  `Processes pending actions that have expired and checks for user reactions to determine if actions should execute or be cancelled.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := context.Background()
@@ -49,11 +49,13 @@ func newProcessPendingCmd() *cobra.Command {
 				return fmt.Errorf("failed to create GitHub client: %w", err)
 			}
 
-			vdb, err := vectordb.NewClient(&cfg.Qdrant)
+			// Transfers need the indexer for copies and the triage agent for
+			// classify_on_arrival, like transfers made by the pipeline
+			proc, err := pipeline.NewUnifiedProcessorWithTransferToken(cfg, dryRun, true, os.Getenv("TRANSFER_TOKEN"))
 			if err != nil {
-				return fmt.Errorf("failed to create vector DB client: %w", err)
+				return fmt.Errorf("failed to create processor: %w", err)
 			}
-			defer vdb.Close()
+			defer proc.Close()
 
 			// Create pending manager once (reused for all repos)
 			pendingMgr := pending.NewManager(gh, cfg)
@@ -67,6 +69,16 @@ func newProcessPendingCmd() *cobra.Command {
 
 				fmt.Printf("Processing pending actions for %s/%s...\n", repoConfig.Org, repoConfig.Repo)
 
+				duplicateChecker := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&cfg.Triage.Duplicate, gh, cfg, dryRun)
+				triageExecutor := triage.NewExecutorWithDelayedActions(gh, cfg, duplicateChecker, dryRun)
+
+				// Schedule closes for issues waiting on their author
+				if n, err := triageExecutor.ScheduleNeedsInfoCloses(ctx, repoConfig.Org, repoConfig.Repo); err != nil {
+					fmt.Printf("Warning: failed to schedule needs-info closes: %v\n", err)
+				} else if n > 0 {
+					fmt.Printf("Scheduled %d needs-info closes\n", n)
+				}
+
 				// Find pending actions
 				actions, err := pendingMgr.FindPendingActions(ctx, repoConfig.Org, repoConfig.Repo)
 				if err != nil {
@@ -80,7 +92,7 @@ func newProcessPendingCmd() *cobra.Command {
 
 					switch action.Type {
 					case pending.ActionTypeTransfer:
-						executor := transfer.NewExecutor(gh, gh, vdb, cfg, dryRun)
+						executor := proc.TransferExecutor()
 						if err := executor.ProcessPendingTransfer(ctx, action); err != nil {
 							fmt.Printf("Error processing transfer: %v\n", err)
 							continue
@@ -88,12 +100,18 @@ func newProcessPendingCmd() *cobra.Command {
 						processedCount++
 
 					case pending.ActionTypeClose:
-						duplicateChecker := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&cfg.Triage.Duplicate, gh, cfg, dryRun)
 						if err := duplicateChecker.ProcessPendingClose(ctx, action); err != nil {
 							fmt.Printf("Error processing close: %v\n", err)
 							continue
 						}
 						processedCount++
+
+					case pending.ActionTypeTriage, pending.ActionTypeNeedsInfo:
+						if err := triageExecutor.ProcessPendingAction(ctx, action); err != nil {
+							fmt.Printf("Error processing %s action: %v\n", action.Type, err)
+							continue
+						}
+						processedCount++
 					}
 				}
 			}
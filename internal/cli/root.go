@@ -40,6 +40,11 @@ func init() {
 	rootCmd.AddCommand(newTriageExecuteCmd())
 	rootCmd.AddCommand(newProcessPendingCmd())
 	rootCmd.AddCommand(newFullProcessCmd())
+	rootCmd.AddCommand(newClusterCmd())
+	rootCmd.AddCommand(newEvalCmd())
+	rootCmd.AddCommand(newRouteCmd())
+	rootCmd.AddCommand(newSweepCmd())
+	rootCmd.AddCommand(newHistoryCmd())
 	rootCmd.AddCommand(newVersionCmd())
 }
 
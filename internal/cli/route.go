@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/embedding"
+	"github.com/Kavirubc/gh-simili/internal/eval"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+func newRouteCmd() *cobra.Command {
+	var (
+		repo     string
+		since    string
+		strategy string
+		format   string
+		output   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "route",
+		Short: "Report where the router would move recent issues",
+		Long: `Run the issue router over open issues created recently and print the
+repositories it would move them to, with reasons. Nothing is transferred.
+
+The router runs even when it is disabled in the config, so repository
+descriptions and thresholds can be tuned before enabling it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			if format != "table" && format != "json" {
+				return fmt.Errorf("invalid format: %s (expected table or json)", format)
+			}
+
+			sinceTime, err := processor.ParseSinceDuration(since)
+			if err != nil {
+				return fmt.Errorf("invalid since duration: %w", err)
+			}
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			cfg.Triage.Router.Enabled = true
+			if strategy != "" {
+				cfg.Triage.Router.Strategy = strategy
+			}
+
+			var (
+				llmProvider llm.Provider
+				scorer      triage.RepoScorer
+			)
+			switch cfg.Triage.Router.Strategy {
+			case "semantic":
+				embedder, err := embedding.NewFallbackProvider(&cfg.Embedding)
+				if err != nil {
+					return fmt.Errorf("failed to create embedder: %w", err)
+				}
+				defer embedder.Close()
+
+				vdb, err := vectordb.NewClient(&cfg.Qdrant)
+				if err != nil {
+					return fmt.Errorf("failed to create vector DB client: %w", err)
+				}
+				defer vdb.Close()
+
+				scorer = processor.NewSimilarityFinder(cfg, embedder, vdb)
+			case "llm":
+				llmProvider, err = createLLMProvider(&cfg.Triage.LLM)
+				if err != nil {
+					return fmt.Errorf("failed to create LLM provider: %w", err)
+				}
+				defer llmProvider.Close()
+			default:
+				return fmt.Errorf("invalid strategy: %s (expected llm or semantic)", cfg.Triage.Router.Strategy)
+			}
+
+			router := triage.NewIssueRouter(cfg, llmProvider, scorer)
+			if router == nil {
+				return fmt.Errorf("router is not available for strategy %s", cfg.Triage.Router.Strategy)
+			}
+
+			ghClient, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			report, err := eval.RouteIssues(ctx, ghClient, router, cfg, repo, sinceTime)
+			if err != nil {
+				return fmt.Errorf("routing failed: %w", err)
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if format == "json" {
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("failed to write report: %w", err)
+				}
+			} else {
+				eval.WriteRouteTable(w, report)
+			}
+
+			if output != "" {
+				fmt.Printf("Report written to: %s\n", output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository to route issues from (owner/repo)")
+	cmd.Flags().StringVar(&since, "since", "30d", "route issues created since (e.g., 24h, 30d)")
+	cmd.Flags().StringVar(&strategy, "strategy", "", "routing strategy: llm or semantic (default: from config)")
+	cmd.Flags().StringVar(&format, "format", "table", "output format: table or json")
+	cmd.Flags().StringVar(&output, "output", "", "path to write the report (default: stdout)")
+	_ = cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/stale"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+func newSweepCmd() *cobra.Command {
+	var (
+		repo string
+		days int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Schedule actions for stale issues",
+		Long: `Find open issues without activity for stale.days and schedule a pending
+action for each: closing it as a duplicate when a newer active issue or an
+already fixed one covers the same problem, or a stale notice otherwise.
+
+Issues with an exempt label (stale.exempt_labels plus the repository's
+stale_exempt_labels) or an action already pending are skipped. Scheduled
+actions run through process-pending and can be approved or cancelled like
+any other delayed action.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if days > 0 {
+				cfg.Stale.Days = days
+			}
+
+			if errs := config.Validate(cfg); len(errs) > 0 {
+				for _, e := range errs {
+					fmt.Printf("config error: %v\n", e)
+				}
+				return fmt.Errorf("invalid configuration")
+			}
+
+			repos := cfg.Repositories
+			if repo != "" {
+				org, name, err := github.ParseRepo(repo)
+				if err != nil {
+					return err
+				}
+				repos = nil
+				for _, r := range cfg.Repositories {
+					if strings.EqualFold(r.Org, org) && strings.EqualFold(r.Repo, name) {
+						repos = append(repos, r)
+					}
+				}
+				if len(repos) == 0 {
+					return fmt.Errorf("repository %s is not configured", repo)
+				}
+			}
+
+			gh, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			vdb, err := vectordb.NewClient(&cfg.Qdrant)
+			if err != nil {
+				return fmt.Errorf("failed to create vector DB client: %w", err)
+			}
+			defer vdb.Close()
+
+			sweeper := stale.NewSweeper(gh, vdb, cfg, dryRun)
+
+			total := 0
+			for _, r := range repos {
+				if !r.Enabled {
+					continue
+				}
+
+				fmt.Printf("Sweeping %s/%s for issues inactive for %d days...\n", r.Org, r.Repo, cfg.Stale.Days)
+				candidates, err := sweeper.Sweep(ctx, r, time.Now())
+				if err != nil {
+					return fmt.Errorf("sweep of %s/%s failed: %w", r.Org, r.Repo, err)
+				}
+
+				for _, c := range candidates {
+					if c.Outcome == stale.OutcomeDuplicate {
+						fmt.Printf("  #%d  close as duplicate of %s/%s#%d (%.0f%%)\n", c.Issue.Number, c.CoveredBy.Org, c.CoveredBy.Repo, c.CoveredBy.Number, c.Score*100)
+					} else {
+						fmt.Printf("  #%d  stale notice\n", c.Issue.Number)
+					}
+				}
+				total += len(candidates)
+			}
+
+			fmt.Printf("Scheduled actions for %d stale issues\n", total)
+			if dryRun {
+				fmt.Println("Dry run: nothing was scheduled")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "only sweep this repository (owner/repo)")
+	cmd.Flags().IntVar(&days, "days", 0, "days without activity (default: stale.days)")
+
+	return cmd
+}
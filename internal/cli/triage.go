@@ -117,7 +117,7 @@ or execute actions directly.`,
 					duplicateChecker := triage.NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, ghClient, cfg)
 					executor = triage.NewExecutorWithDelayedActions(ghClient, cfg, duplicateChecker, dryRun)
 				} else {
-					executor = triage.NewExecutor(ghClient, dryRun)
+					executor = triage.NewExecutor(ghClient, cfg, dryRun)
 				}
 				if err := executor.Execute(ctx, issue, result); err != nil {
 					return fmt.Errorf("failed to execute actions: %w", err)
@@ -133,6 +133,8 @@ or execute actions directly.`,
 	cmd.Flags().BoolVar(&execute, "execute", false, "execute actions (default: analyze only)")
 	_ = cmd.MarkPersistentFlagRequired("event-path")
 
+	cmd.AddCommand(newTriageEvalCmd())
+
 	return cmd
 }
 
@@ -225,16 +227,18 @@ func newTriageExecuteCmd() *cobra.Command {
 
 			// Load config to check for delayed actions
 			cfgPath := config.FindConfigPath(cfgFile)
-			var executor *triage.Executor
+			var cfg *config.Config
 			if cfgPath != "" {
-				cfg, err := config.Load(cfgPath)
-				if err == nil && cfg.Defaults.DelayedActions.Enabled {
-					duplicateChecker := triage.NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, ghClient, cfg)
-					executor = triage.NewExecutorWithDelayedActions(ghClient, cfg, duplicateChecker, dryRun)
+				if loaded, err := config.Load(cfgPath); err == nil {
+					cfg = loaded
 				}
 			}
-			if executor == nil {
-				executor = triage.NewExecutor(ghClient, dryRun)
+			var executor *triage.Executor
+			if cfg != nil && cfg.Defaults.DelayedActions.Enabled {
+				duplicateChecker := triage.NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, ghClient, cfg)
+				executor = triage.NewExecutorWithDelayedActions(ghClient, cfg, duplicateChecker, dryRun)
+			} else {
+				executor = triage.NewExecutor(ghClient, cfg, dryRun)
 			}
 
 			if err := executor.Execute(ctx, &issue, result); err != nil {
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/embedding"
+	"github.com/Kavirubc/gh-simili/internal/eval"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/spf13/cobra"
+)
+
+// newTriageEvalCmd creates a command that measures classifier agreement with maintainers
+func newTriageEvalCmd() *cobra.Command {
+	var (
+		repo      string
+		sample    int
+		cachePath string
+		format    string
+		output    string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "Evaluate label classification against maintainer labels",
+		Long: `Run the label classifier on recent issues with their labels hidden and compare
+the suggestions with the labels maintainers applied. Reports per-label
+precision, recall, disagreements and a suggested min_confidence.
+
+Only closed issues and issues carrying a known label are sampled, so open
+issues nobody has triaged yet do not count as "no label".
+
+LLM responses are cached in a local file so reruns are cheap.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			if format != "table" && format != "json" {
+				return fmt.Errorf("invalid format: %s (expected table or json)", format)
+			}
+
+			cfgPath := config.FindConfigPath(cfgFile)
+			if cfgPath == "" {
+				return fmt.Errorf("config file not found")
+			}
+
+			cfg, err := config.Load(cfgPath)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+
+			if len(cfg.Triage.Classifier.Labels) == 0 {
+				return fmt.Errorf("no classifier labels configured")
+			}
+
+			provider, err := createLLMProvider(&cfg.Triage.LLM)
+			if err != nil {
+				return fmt.Errorf("failed to create LLM provider: %w", err)
+			}
+
+			var llmProvider llm.Provider = provider
+			if cachePath != "" {
+				cached, err := llm.NewCachedProvider(provider, cachePath)
+				if err != nil {
+					provider.Close()
+					return err
+				}
+				llmProvider = cached
+			}
+			defer func() {
+				if err := llmProvider.Close(); err != nil {
+					log.Printf("Warning: %v", err)
+				}
+			}()
+
+			ghClient, err := github.NewClient()
+			if err != nil {
+				return fmt.Errorf("failed to create GitHub client: %w", err)
+			}
+
+			// Labeled examples come from the index, excluding the issue being classified
+			var examples triage.ExampleSource
+			if cfg.Triage.Classifier.Examples > 0 {
+				embedder, err := embedding.NewFallbackProvider(&cfg.Embedding)
+				if err != nil {
+					return fmt.Errorf("failed to create embedder: %w", err)
+				}
+				defer embedder.Close()
+
+				vdb, err := vectordb.NewClient(&cfg.Qdrant)
+				if err != nil {
+					return fmt.Errorf("failed to create vector DB client: %w", err)
+				}
+				defer vdb.Close()
+
+				examples = processor.NewSimilarityFinder(cfg, embedder, vdb)
+			}
+
+			report, err := eval.EvaluateClassifier(ctx, ghClient, llmProvider, examples, &cfg.Triage.Classifier, repo, sample)
+			if err != nil {
+				return fmt.Errorf("evaluation failed: %w", err)
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create output file: %w", err)
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if format == "json" {
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(report); err != nil {
+					return fmt.Errorf("failed to write report: %w", err)
+				}
+			} else {
+				eval.WriteLabelTable(w, report)
+			}
+
+			if output != "" {
+				fmt.Printf("Report written to: %s\n", output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&repo, "repo", "", "repository to evaluate (owner/repo)")
+	cmd.Flags().IntVar(&sample, "sample", 50, "number of recent closed or labeled issues to classify")
+	cmd.Flags().StringVar(&cachePath, "cache", ".simili/llm-cache.json", "file caching LLM responses (empty to disable)")
+	cmd.Flags().StringVar(&format, "format", "table", "output format: table or json")
+	cmd.Flags().StringVar(&output, "output", "", "path to write the report (default: stdout)")
+	_ = cmd.MarkFlagRequired("repo")
+
+	return cmd
+}
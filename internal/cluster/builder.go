@@ -0,0 +1,152 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// neighborLimit is the number of neighbors inspected per issue when building clusters
+const neighborLimit = 10
+
+// BuildStats summarizes a clustering run
+type BuildStats struct {
+	Issues   int `json:"issues"`
+	Clusters int `json:"clusters"`
+	Members  int `json:"members"`
+	Updated  int `json:"updated"`
+}
+
+// Build groups the indexed issues of a repository into clusters of issues
+// whose similarity meets the configured threshold. Existing cluster IDs are
+// reused so links from earlier runs stay stable.
+func (m *Manager) Build(ctx context.Context, org, repo string, dryRun bool) (*BuildStats, error) {
+	collection := vectordb.CollectionName(org)
+
+	issues, err := m.vdb.RepoIssues(ctx, collection, org, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &BuildStats{Issues: len(issues)}
+	uf := newUnionFind()
+	byKey := make(map[string]models.Issue, len(issues))
+
+	for i := range issues {
+		issue := &issues[i]
+		byKey[issue.UUID()] = *issue
+
+		neighbors, err := m.vdb.SearchByIssue(ctx, collection, issue, neighborLimit, m.cfg.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search neighbors of #%d: %w", issue.Number, err)
+		}
+		for _, n := range neighbors {
+			byKey[n.Issue.UUID()] = n.Issue
+			uf.union(issue.UUID(), n.Issue.UUID())
+		}
+	}
+
+	for _, group := range uf.groups() {
+		if len(group) < 2 {
+			continue
+		}
+		stats.Clusters++
+		stats.Members += len(group)
+
+		members := make([]models.Issue, len(group))
+		for i, key := range group {
+			members[i] = byKey[key]
+		}
+
+		clusterID := clusterIDFor(members)
+		for _, member := range members {
+			if member.ClusterID == clusterID {
+				continue
+			}
+			stats.Updated++
+			if dryRun {
+				continue
+			}
+			if err := m.vdb.SetCluster(ctx, collection, member.Org, member.Repo, member.Number, clusterID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return stats, nil
+}
+
+// clusterIDFor keeps the most common existing cluster ID among members, or
+// derives a new one from the oldest member
+func clusterIDFor(members []models.Issue) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, m := range members {
+		if m.ClusterID == "" {
+			continue
+		}
+		counts[m.ClusterID]++
+		if best == "" || counts[m.ClusterID] > counts[best] {
+			best = m.ClusterID
+		}
+	}
+	if best != "" {
+		return best
+	}
+
+	oldest := members[0]
+	for _, m := range members[1:] {
+		if m.CreatedAt.Before(oldest.CreatedAt) {
+			oldest = m
+		}
+	}
+	return oldest.UUID()
+}
+
+// unionFind groups keys into disjoint sets
+type unionFind struct {
+	parent map[string]string
+	order  []string
+}
+
+func newUnionFind() *unionFind {
+	return &unionFind{parent: make(map[string]string)}
+}
+
+func (u *unionFind) find(key string) string {
+	if _, ok := u.parent[key]; !ok {
+		u.parent[key] = key
+		u.order = append(u.order, key)
+	}
+	for u.parent[key] != key {
+		u.parent[key] = u.parent[u.parent[key]]
+		key = u.parent[key]
+	}
+	return key
+}
+
+func (u *unionFind) union(a, b string) {
+	ra, rb := u.find(a), u.find(b)
+	if ra != rb {
+		u.parent[rb] = ra
+	}
+}
+
+// groups returns the sets in first-seen order
+func (u *unionFind) groups() [][]string {
+	index := make(map[string]int)
+	var groups [][]string
+	for _, key := range u.order {
+		root := u.find(key)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], key)
+	}
+	return groups
+}
@@ -0,0 +1,125 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Canonical selection strategies
+const (
+	StrategyOldestOpen      = "oldest_open"
+	StrategyMostReactions   = "most_reactions"
+	StrategyMaintainerLabel = "maintainer_label"
+)
+
+// Manager groups duplicate issues into clusters stored in the vector payload
+type Manager struct {
+	vdb *vectordb.Client
+	cfg *config.ClusteringConfig
+}
+
+// NewManager creates a new cluster manager
+func NewManager(vdb *vectordb.Client, cfg *config.ClusteringConfig) *Manager {
+	return &Manager{
+		vdb: vdb,
+		cfg: cfg,
+	}
+}
+
+// Canonical returns the canonical issue of the cluster that issue belongs to.
+// Issues outside any cluster are their own canonical issue.
+func (m *Manager) Canonical(ctx context.Context, issue *models.Issue) (*models.Issue, error) {
+	if issue.ClusterID == "" {
+		return issue, nil
+	}
+
+	members, err := m.vdb.ClusterMembers(ctx, vectordb.CollectionName(issue.Org), issue.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return issue, nil
+	}
+
+	return SelectCanonical(members, m.cfg.Canonical, m.cfg.CanonicalLabel), nil
+}
+
+// Join adds issue to the cluster of match, creating the cluster when match
+// has none yet. The cluster ID is set on issue so the next upsert keeps it.
+func (m *Manager) Join(ctx context.Context, issue, match *models.Issue) (string, error) {
+	collection := vectordb.CollectionName(match.Org)
+
+	clusterID := match.ClusterID
+	if clusterID == "" {
+		clusterID = match.UUID()
+		if err := m.vdb.SetCluster(ctx, collection, match.Org, match.Repo, match.Number, clusterID); err != nil {
+			return "", fmt.Errorf("failed to create cluster: %w", err)
+		}
+		match.ClusterID = clusterID
+	}
+
+	// The issue may not be indexed yet, in which case this is a no-op and the
+	// cluster ID is written when it is upserted
+	if err := m.vdb.SetCluster(ctx, vectordb.CollectionName(issue.Org), issue.Org, issue.Repo, issue.Number, clusterID); err != nil {
+		return "", fmt.Errorf("failed to join cluster: %w", err)
+	}
+	issue.ClusterID = clusterID
+
+	return clusterID, nil
+}
+
+// SelectCanonical picks the canonical issue among cluster members. Open issues
+// are always preferred; ties and fallbacks resolve to the oldest issue.
+func SelectCanonical(members []models.Issue, strategy, label string) *models.Issue {
+	if len(members) == 0 {
+		return nil
+	}
+
+	candidates := make([]models.Issue, 0, len(members))
+	for _, m := range members {
+		if m.State == "open" {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = append(candidates, members...)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].CreatedAt.Before(candidates[j].CreatedAt)
+	})
+
+	switch strategy {
+	case StrategyMostReactions:
+		best := 0
+		for i := range candidates {
+			if candidates[i].Reactions > candidates[best].Reactions {
+				best = i
+			}
+		}
+		return &candidates[best]
+	case StrategyMaintainerLabel:
+		for i := range candidates {
+			if hasLabel(&candidates[i], label) {
+				return &candidates[i]
+			}
+		}
+	}
+
+	return &candidates[0]
+}
+
+// hasLabel reports whether issue carries label
+func hasLabel(issue *models.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
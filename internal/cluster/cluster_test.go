@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestSelectCanonical(t *testing.T) {
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	members := []models.Issue{
+		{Number: 3, State: "open", Reactions: 12, CreatedAt: base.Add(48 * time.Hour)},
+		{Number: 1, State: "closed", Reactions: 30, CreatedAt: base},
+		{Number: 2, State: "open", Reactions: 4, CreatedAt: base.Add(24 * time.Hour)},
+		{Number: 4, State: "open", Labels: []string{"canonical"}, CreatedAt: base.Add(72 * time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		members  []models.Issue
+		strategy string
+		want     int
+	}{
+		{"oldest open", members, StrategyOldestOpen, 2},
+		{"most reactions among open", members, StrategyMostReactions, 3},
+		{"maintainer label", members, StrategyMaintainerLabel, 4},
+		{"label missing falls back to oldest open", members[:3], StrategyMaintainerLabel, 2},
+		{"all closed uses oldest", []models.Issue{
+			{Number: 7, State: "closed", CreatedAt: base.Add(time.Hour)},
+			{Number: 6, State: "closed", CreatedAt: base},
+		}, StrategyOldestOpen, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SelectCanonical(tt.members, tt.strategy, "canonical")
+			if got == nil || got.Number != tt.want {
+				t.Errorf("SelectCanonical() = %v, want #%d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnionFindGroups(t *testing.T) {
+	uf := newUnionFind()
+	uf.union("a", "b")
+	uf.union("c", "d")
+	uf.union("b", "d")
+	uf.find("e")
+
+	groups := uf.groups()
+	if len(groups) != 2 {
+		t.Fatalf("groups() returned %d groups, want 2", len(groups))
+	}
+	if len(groups[0]) != 4 || len(groups[1]) != 1 {
+		t.Errorf("groups() = %v, want one group of 4 and one singleton", groups)
+	}
+}
@@ -0,0 +1,86 @@
+// Package command parses the slash commands maintainers write in issue
+// comments, such as "/simili approve" or "/simili transfer org/repo".
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+)
+
+// DefaultPrefix starts every command
+const DefaultPrefix = "/simili"
+
+// Supported commands
+const (
+	Approve      = "approve"
+	Cancel       = "cancel"
+	Transfer     = "transfer"
+	NotDuplicate = "not-duplicate"
+	Retriage     = "retriage"
+	Similar      = "similar"
+)
+
+// Command is a single parsed slash command
+type Command struct {
+	Name string
+	Args []string
+}
+
+// String renders the command as it would be typed, without the prefix
+func (c Command) String() string {
+	return strings.TrimSpace(c.Name + " " + strings.Join(c.Args, " "))
+}
+
+// Parse extracts the commands from a comment body. Each command is a line
+// starting with the prefix; lines inside fenced code blocks and quotes are
+// ignored so that examples and replies are not run.
+func Parse(body, prefix string) []Command {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+
+	var commands []Command
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || strings.HasPrefix(line, ">") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], prefix) {
+			continue
+		}
+		commands = append(commands, Command{
+			Name: strings.ToLower(fields[1]),
+			Args: fields[2:],
+		})
+	}
+	return commands
+}
+
+// Validate checks that a command is known and has the arguments it needs
+func (c Command) Validate() error {
+	switch c.Name {
+	case Approve, Cancel, NotDuplicate, Retriage, Similar:
+		if len(c.Args) > 0 {
+			return fmt.Errorf("`%s` takes no arguments", c.Name)
+		}
+	case Transfer:
+		if len(c.Args) != 1 {
+			return fmt.Errorf("usage: `transfer org/repo`")
+		}
+		if org, repo, err := github.ParseRepo(c.Args[0]); err != nil || org == "" || repo == "" {
+			return fmt.Errorf("invalid target repository %q", c.Args[0])
+		}
+	default:
+		return fmt.Errorf("unknown command `%s`", c.Name)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []Command
+	}{
+		{
+			name: "single command",
+			body: "/simili approve",
+			want: []Command{{Name: Approve, Args: []string{}}},
+		},
+		{
+			name: "command with argument among text",
+			body: "This belongs elsewhere.\n  /simili Transfer org/backend  \nThanks!",
+			want: []Command{{Name: Transfer, Args: []string{"org/backend"}}},
+		},
+		{
+			name: "several commands",
+			body: "/simili not-duplicate\n/simili retriage",
+			want: []Command{{Name: NotDuplicate, Args: []string{}}, {Name: Retriage, Args: []string{}}},
+		},
+		{
+			name: "quotes and code blocks are ignored",
+			body: "> /simili cancel\n```\n/simili approve\n```\nsee `/simili similar`",
+			want: nil,
+		},
+		{
+			name: "prefix without command",
+			body: "/simili",
+			want: nil,
+		},
+		{
+			name: "prefix must start the line",
+			body: "please run /simili approve",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.body, "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		cmd     Command
+		wantErr bool
+	}{
+		{Command{Name: Approve}, false},
+		{Command{Name: Cancel, Args: []string{"now"}}, true},
+		{Command{Name: Transfer, Args: []string{"org/repo"}}, false},
+		{Command{Name: Transfer}, true},
+		{Command{Name: Transfer, Args: []string{"org/"}}, true},
+		{Command{Name: "close"}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.cmd.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%s) error = %v, wantErr %v", tt.cmd, err, tt.wantErr)
+		}
+	}
+}
@@ -22,6 +22,51 @@ type Config struct {
 	Repositories []RepositoryConfig `yaml:"repositories"`
 	RateLimits   RateLimitsConfig   `yaml:"rate_limits"`
 	Pipeline     PipelineConfig     `yaml:"pipeline"`
+	Rerank       RerankConfig       `yaml:"rerank"`
+	Clustering   ClusteringConfig   `yaml:"clustering"`
+	Commands     CommandsConfig     `yaml:"commands"`
+	Stale        StaleConfig        `yaml:"stale"`
+	Audit        AuditConfig        `yaml:"audit"`
+}
+
+// AuditConfig contains settings for the audit log of changes made to issues
+type AuditConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"` // JSON Lines file, appended to
+}
+
+// StaleConfig contains settings for the stale issue sweeper
+type StaleConfig struct {
+	Days               int      `yaml:"days"`                // days without activity before an issue is swept
+	DuplicateThreshold float64  `yaml:"duplicate_threshold"` // minimum similarity to close as a duplicate of a covering issue
+	Label              string   `yaml:"label"`               // label added with the stale notice
+	Notice             string   `yaml:"notice"`              // comment posted on stale issues
+	ExemptLabels       []string `yaml:"exempt_labels"`       // issues with any of these labels are never swept
+}
+
+// CommandsConfig contains settings for slash commands in issue comments
+type CommandsConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	Prefix        string `yaml:"prefix"`         // defaults to "/simili"
+	MinPermission string `yaml:"min_permission"` // repository role needed: read, triage, write, maintain or admin
+}
+
+// ClusteringConfig contains settings for grouping duplicates into clusters
+type ClusteringConfig struct {
+	Enabled        bool    `yaml:"enabled"`
+	Threshold      float64 `yaml:"threshold"`       // minimum similarity for two issues to share a cluster
+	Canonical      string  `yaml:"canonical"`       // "oldest_open", "most_reactions" or "maintainer_label"
+	CanonicalLabel string  `yaml:"canonical_label"` // label maintainers use to mark the canonical issue
+}
+
+// RerankConfig contains settings for re-ranking similarity results
+type RerankConfig struct {
+	Enabled  bool    `yaml:"enabled"`
+	Provider string  `yaml:"provider"` // "llm" or "cross_encoder"
+	TopK     int     `yaml:"top_k"`
+	Endpoint string  `yaml:"endpoint,omitempty"` // cross-encoder /rerank URL
+	APIKey   string  `yaml:"api_key,omitempty"`
+	MinScore float64 `yaml:"min_score"` // cross-encoder scores below this are dropped
 }
 
 // TriageConfig contains issue triage settings
@@ -43,15 +88,42 @@ type LLMConfig struct {
 
 // ClassifierConfig contains label classification settings
 type ClassifierConfig struct {
-	Enabled       bool          `yaml:"enabled"`
-	Labels        []LabelConfig `yaml:"labels"`
-	MinConfidence float64       `yaml:"min_confidence"`
+	Enabled       bool               `yaml:"enabled"`
+	Labels        []LabelConfig      `yaml:"labels"`
+	MinConfidence float64            `yaml:"min_confidence"`
+	Mode          string             `yaml:"mode"`            // "llm" or "knn" (vote among similar labeled issues, no LLM)
+	Examples      int                `yaml:"examples"`        // similar labeled issues used as few-shot examples or kNN voters
+	SyncLabels    bool               `yaml:"sync_labels"`     // load labels and descriptions from each repository
+	Allow         []string           `yaml:"allow,omitempty"` // label patterns that may be suggested (all when empty)
+	Deny          []string           `yaml:"deny,omitempty"`  // label patterns that are never suggested, e.g. "priority/*"
+	Groups        []LabelGroupConfig `yaml:"groups,omitempty"`
+}
+
+// LabelGroupConfig groups related labels, e.g. "type/*" or "priority/*".
+// An issue carries at most one label from an exclusive group.
+type LabelGroupConfig struct {
+	Name      string   `yaml:"name"`
+	Exclusive bool     `yaml:"exclusive"` // keep only the highest-confidence label and remove conflicting ones
+	Labels    []string `yaml:"labels"`    // label names or patterns
 }
 
 // LabelConfig defines a label with optional matching keywords
 type LabelConfig struct {
-	Name     string   `yaml:"name"`
-	Keywords []string `yaml:"keywords,omitempty"`
+	Name        string        `yaml:"name"`
+	Description string        `yaml:"description,omitempty"` // what the label means, shown to the LLM
+	Keywords    []string      `yaml:"keywords,omitempty"`    // plain substrings; one match is enough to suggest the label
+	Rules       []KeywordRule `yaml:"rules,omitempty"`
+}
+
+// KeywordRule is a weighted pattern matched against part of an issue.
+// Matches combine so that each additional match raises confidence; a
+// negative weight lowers it, and -1 rules the label out entirely.
+type KeywordRule struct {
+	Pattern   string  `yaml:"pattern"`
+	Regex     bool    `yaml:"regex,omitempty"`      // treat pattern as a regular expression
+	WholeWord bool    `yaml:"whole_word,omitempty"` // only match at word boundaries
+	Field     string  `yaml:"field,omitempty"`      // "title", "body", "code" or empty for title and body
+	Weight    float64 `yaml:"weight,omitempty"`     // between -1 and 1, default 0.5
 }
 
 // QualityConfig contains quality detection settings
@@ -66,11 +138,24 @@ type DuplicateConfig struct {
 	Enabled            bool    `yaml:"enabled"`
 	AutoCloseThreshold float64 `yaml:"auto_close_threshold"`
 	RequireConfirm     bool    `yaml:"require_confirmation"`
+	LLMVerify          bool    `yaml:"llm_verify"`     // auto-close also requires an LLM "duplicate" verdict
+	BackLink           bool    `yaml:"back_link"`      // keep a comment listing all duplicates on the original
+	MentionAuthor      bool    `yaml:"mention_author"` // mention the duplicate's author on the original
 }
 
-// RouterConfig contains AI-based issue routing settings
+// RouterConfig contains issue routing settings
 type RouterConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled   bool    `yaml:"enabled"`
+	Strategy  string  `yaml:"strategy"`  // "llm" (repository descriptions) or "semantic" (indexed issues, no LLM)
+	Method    string  `yaml:"method"`    // semantic: "centroid" or "knn"
+	Neighbors int     `yaml:"neighbors"` // semantic knn: nearest issues that vote
+	Margin    float64 `yaml:"margin"`    // semantic: how much the target must beat the current repo's score
+
+	// MinConfidence is the router confidence needed to act on a suggestion.
+	// Defaults to 0.8 for the llm strategy; semantic suggestions are gated by Margin
+	// unless it is set.
+	MinConfidence float64 `yaml:"min_confidence"`
+	SuggestOnly   bool    `yaml:"suggest_only"` // mention the suggested repo in the summary instead of transferring
 }
 
 // QdrantConfig contains Qdrant connection settings
@@ -82,8 +167,27 @@ type QdrantConfig struct {
 
 // EmbeddingConfig contains embedding provider settings
 type EmbeddingConfig struct {
-	Primary  ProviderConfig `yaml:"primary"`
-	Fallback ProviderConfig `yaml:"fallback"`
+	Primary   ProviderConfig  `yaml:"primary"`
+	Fallback  ProviderConfig  `yaml:"fallback"`
+	Normalize NormalizeConfig `yaml:"normalize"`
+	Comments  CommentsConfig  `yaml:"comments"`
+}
+
+// CommentsConfig controls whether issue comments are embedded alongside the issue
+type CommentsConfig struct {
+	Enabled     bool   `yaml:"enabled"`
+	Mode        string `yaml:"mode"`    // "fold" into the issue text or "separate" points
+	RankBy      string `yaml:"rank_by"` // "reactions" or "maintainer"
+	MaxComments int    `yaml:"max_comments"`
+}
+
+// NormalizeConfig controls how issue text is cleaned before embedding
+type NormalizeConfig struct {
+	Enabled       bool   `yaml:"enabled"`
+	TemplateDir   string `yaml:"template_dir,omitempty"` // e.g. ".github/ISSUE_TEMPLATE"
+	ExtractErrors bool   `yaml:"extract_errors"`
+	ErrorWeight   int    `yaml:"error_weight"` // times the errors section is repeated
+	MaxLength     int    `yaml:"max_length"`
 }
 
 // ProviderConfig contains settings for an embedding provider
@@ -113,6 +217,32 @@ type DelayedActionsConfig struct {
 	CancelReaction      string `yaml:"cancel_reaction"`
 	ExecuteOnApprove    bool   `yaml:"execute_on_approve"`
 	OptimisticTransfers bool   `yaml:"optimistic_transfers"`
+
+	Authorization ReactionAuthConfig `yaml:"authorization"`
+
+	Store     string `yaml:"store"`      // "comments" (labels and comment metadata) or "file"
+	StorePath string `yaml:"store_path"` // file store database
+
+	SigningSecret string   `yaml:"signing_secret"` // HMAC key for pending action metadata
+	BotLogins     []string `yaml:"bot_logins"`     // accounts whose comments may carry pending actions
+
+	Actions   []string        `yaml:"actions"`    // triage actions that also wait: add_label, remove_label, comment, lock, assign
+	NeedsInfo NeedsInfoConfig `yaml:"needs_info"` // auto-close issues waiting on their author
+}
+
+// NeedsInfoConfig closes issues labeled as needing information when the
+// author does not reply, and reopens them when the author comes back
+type NeedsInfoConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Days    int  `yaml:"days"` // days without an author reply before closing
+}
+
+// ReactionAuthConfig controls whose reactions approve or cancel delayed actions
+type ReactionAuthConfig struct {
+	Roles         []string `yaml:"roles"`          // "author", "collaborator" and/or "anyone"
+	MinPermission string   `yaml:"min_permission"` // collaborator role needed: read, triage, write, maintain or admin
+	Teams         []string `yaml:"teams"`          // members of these teams ("org/team-slug") are also allowed
+	Quorum        int      `yaml:"quorum"`         // authorized users needed to approve or cancel
 }
 
 // RepositoryConfig contains settings for a specific repository
@@ -121,23 +251,70 @@ type RepositoryConfig struct {
 	Repo                string         `yaml:"repo"`
 	Enabled             bool           `yaml:"enabled"`
 	SimilarityThreshold float64        `yaml:"similarity_threshold,omitempty"`
+	RouterThreshold     float64        `yaml:"router_threshold,omitempty"` // minimum router confidence for issues in this repo
 	Description         string         `yaml:"description,omitempty"`
 	TransferRules       []TransferRule `yaml:"transfer_rules,omitempty"`
+	StaleExemptLabels   []string       `yaml:"stale_exempt_labels,omitempty"` // added to stale.exempt_labels for this repo
 }
 
 // TransferRule defines when to transfer an issue to another repo
 type TransferRule struct {
-	Match    MatchCondition `yaml:"match"`
-	Target   string         `yaml:"target"`
-	Priority int            `yaml:"priority"`
+	Match        MatchCondition `yaml:"match"`
+	Target       string         `yaml:"target"`
+	Priority     int            `yaml:"priority"`
+	Strategy     string         `yaml:"strategy,omitempty"`      // "auto" (default), "transfer" or "copy"
+	CopyComments bool           `yaml:"copy_comments,omitempty"` // also copy comments when the issue is copied
+
+	// Applied when the issue arrives in the target repository
+	LabelMap          map[string]string `yaml:"label_map,omitempty"`           // source label -> target label
+	AddLabels         []string          `yaml:"add_labels,omitempty"`          // labels added on arrival
+	ClassifyOnArrival bool              `yaml:"classify_on_arrival,omitempty"` // classify against the target repository's labels
+}
+
+// TransferStrategy returns how issues are moved by the rule. The default,
+// "auto", uses GitHub's transfer within an owner and copies the issue and
+// closes the original across owners, which GitHub cannot transfer between.
+func (r *TransferRule) TransferStrategy() string {
+	if r == nil || r.Strategy == "" {
+		return "auto"
+	}
+	return r.Strategy
 }
 
-// MatchCondition defines conditions for matching issues
+// MatchCondition defines conditions for matching issues. Every condition
+// that is set must match; not, any_of and all_of nest further conditions.
 type MatchCondition struct {
-	Labels        []string `yaml:"labels,omitempty"`
-	TitleContains []string `yaml:"title_contains,omitempty"`
-	BodyContains  []string `yaml:"body_contains,omitempty"`
-	Author        string   `yaml:"author,omitempty"`
+	Labels            []string            `yaml:"labels,omitempty"`
+	TitleContains     []string            `yaml:"title_contains,omitempty"`
+	BodyContains      []string            `yaml:"body_contains,omitempty"`
+	TitleRegex        string              `yaml:"title_regex,omitempty"`
+	BodyRegex         string              `yaml:"body_regex,omitempty"`
+	Author            string              `yaml:"author,omitempty"`
+	AuthorAssociation []string            `yaml:"author_association,omitempty"` // e.g. FIRST_TIME_CONTRIBUTOR, MEMBER
+	IssueType         []string            `yaml:"issue_type,omitempty"`         // GitHub issue type, e.g. Bug
+	Fields            map[string][]string `yaml:"fields,omitempty"`             // issue form field label -> accepted values
+	Keywords          []KeywordRule       `yaml:"keywords,omitempty"`
+	KeywordScore      float64             `yaml:"keyword_score,omitempty"` // minimum keyword confidence, any positive score when 0
+	Not               *MatchCondition     `yaml:"not,omitempty"`
+	AnyOf             []MatchCondition    `yaml:"any_of,omitempty"`
+	AllOf             []MatchCondition    `yaml:"all_of,omitempty"`
+}
+
+// IsEmpty reports whether no condition is set
+func (m *MatchCondition) IsEmpty() bool {
+	return len(m.Labels) == 0 &&
+		len(m.TitleContains) == 0 &&
+		len(m.BodyContains) == 0 &&
+		m.TitleRegex == "" &&
+		m.BodyRegex == "" &&
+		m.Author == "" &&
+		len(m.AuthorAssociation) == 0 &&
+		len(m.IssueType) == 0 &&
+		len(m.Fields) == 0 &&
+		len(m.Keywords) == 0 &&
+		m.Not == nil &&
+		len(m.AnyOf) == 0 &&
+		len(m.AllOf) == 0
 }
 
 // RateLimitsConfig contains rate limiting settings
@@ -225,11 +402,84 @@ func applyDefaults(cfg *Config) {
 	if cfg.Embedding.Fallback.Dimensions == 0 {
 		cfg.Embedding.Fallback.Dimensions = 768
 	}
+	if cfg.Embedding.Normalize.ErrorWeight == 0 {
+		cfg.Embedding.Normalize.ErrorWeight = 2
+	}
+	if cfg.Embedding.Normalize.MaxLength == 0 {
+		cfg.Embedding.Normalize.MaxLength = 6000
+	}
+	if cfg.Embedding.Comments.Mode == "" {
+		cfg.Embedding.Comments.Mode = "fold"
+	}
+	if cfg.Embedding.Comments.RankBy == "" {
+		cfg.Embedding.Comments.RankBy = "reactions"
+	}
+	if cfg.Embedding.Comments.MaxComments == 0 {
+		cfg.Embedding.Comments.MaxComments = 3
+	}
+
+	// Rerank defaults
+	if cfg.Rerank.Provider == "" {
+		cfg.Rerank.Provider = "llm"
+	}
+	if cfg.Rerank.TopK == 0 {
+		cfg.Rerank.TopK = 5
+	}
+
+	// Clustering defaults
+	if cfg.Clustering.Threshold == 0 {
+		cfg.Clustering.Threshold = 0.9
+	}
+	if cfg.Clustering.Canonical == "" {
+		cfg.Clustering.Canonical = "oldest_open"
+	}
+	if cfg.Clustering.CanonicalLabel == "" {
+		cfg.Clustering.CanonicalLabel = "canonical"
+	}
+
+	// Audit log defaults
+	if cfg.Audit.Path == "" {
+		cfg.Audit.Path = ".simili/audit.jsonl"
+	}
+
+	// Stale sweeper defaults
+	if cfg.Stale.Days == 0 {
+		cfg.Stale.Days = 90
+	}
+	if cfg.Stale.DuplicateThreshold == 0 {
+		cfg.Stale.DuplicateThreshold = 0.9
+	}
+	if cfg.Stale.Label == "" {
+		cfg.Stale.Label = "stale"
+	}
+	if len(cfg.Stale.ExemptLabels) == 0 {
+		cfg.Stale.ExemptLabels = []string{"pinned", "security"}
+	}
+
+	// Router defaults
+	if cfg.Triage.Router.Strategy == "" {
+		cfg.Triage.Router.Strategy = "llm"
+	}
+	if cfg.Triage.Router.Method == "" {
+		cfg.Triage.Router.Method = "centroid"
+	}
+	if cfg.Triage.Router.Neighbors == 0 {
+		cfg.Triage.Router.Neighbors = 20
+	}
+	if cfg.Triage.Router.Margin == 0 {
+		cfg.Triage.Router.Margin = 0.05
+	}
 
 	// Triage defaults
 	if cfg.Triage.Classifier.MinConfidence == 0 {
 		cfg.Triage.Classifier.MinConfidence = 0.7
 	}
+	if cfg.Triage.Classifier.Mode == "" {
+		cfg.Triage.Classifier.Mode = "llm"
+	}
+	if cfg.Triage.Classifier.Mode == "knn" && cfg.Triage.Classifier.Examples == 0 {
+		cfg.Triage.Classifier.Examples = 10
+	}
 	if cfg.Triage.Quality.MinScore == 0 {
 		cfg.Triage.Quality.MinScore = 0.5
 	}
@@ -250,6 +500,30 @@ func applyDefaults(cfg *Config) {
 	if cfg.Defaults.DelayedActions.CancelReaction == "" {
 		cfg.Defaults.DelayedActions.CancelReaction = "-1"
 	}
+	if cfg.Commands.Prefix == "" {
+		cfg.Commands.Prefix = "/simili"
+	}
+	if cfg.Commands.MinPermission == "" {
+		cfg.Commands.MinPermission = "triage"
+	}
+	if cfg.Defaults.DelayedActions.Store == "" {
+		cfg.Defaults.DelayedActions.Store = "comments"
+	}
+	if cfg.Defaults.DelayedActions.StorePath == "" {
+		cfg.Defaults.DelayedActions.StorePath = ".simili/pending.db"
+	}
+	if len(cfg.Defaults.DelayedActions.Authorization.Roles) == 0 {
+		cfg.Defaults.DelayedActions.Authorization.Roles = []string{"author", "collaborator"}
+	}
+	if cfg.Defaults.DelayedActions.Authorization.MinPermission == "" {
+		cfg.Defaults.DelayedActions.Authorization.MinPermission = "triage"
+	}
+	if cfg.Defaults.DelayedActions.Authorization.Quorum == 0 {
+		cfg.Defaults.DelayedActions.Authorization.Quorum = 1
+	}
+	if cfg.Defaults.DelayedActions.NeedsInfo.Days == 0 {
+		cfg.Defaults.DelayedActions.NeedsInfo.Days = 14
+	}
 	// Enabled defaults to false (zero value) - must be explicitly enabled
 }
 
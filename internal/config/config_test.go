@@ -112,3 +112,87 @@ func TestApplyDefaults(t *testing.T) {
 		t.Errorf("GitHubRPS = %v, want 10", cfg.RateLimits.GitHubRPS)
 	}
 }
+
+func TestValidateMatchCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    MatchCondition
+		wantErr bool
+	}{
+		{"empty", MatchCondition{}, true},
+		{"valid regex", MatchCondition{TitleRegex: `^\[docs\]`}, false},
+		{"invalid regex", MatchCondition{BodyRegex: "("}, true},
+		{"known association", MatchCondition{AuthorAssociation: []string{"first_time_contributor"}}, false},
+		{"unknown association", MatchCondition{AuthorAssociation: []string{"STRANGER"}}, true},
+		{"nested invalid regex", MatchCondition{AnyOf: []MatchCondition{{Labels: []string{"a"}}, {TitleRegex: "["}}}, true},
+		{"empty not", MatchCondition{Labels: []string{"a"}, Not: &MatchCondition{}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateMatchCondition("match", &tt.cond)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateMatchCondition() = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSigningSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		delayed DelayedActionsConfig
+		wantErr bool
+	}{
+		{"disabled", DelayedActionsConfig{Store: "comments"}, false},
+		{"comments without secret", DelayedActionsConfig{Enabled: true, Store: "comments"}, true},
+		{"comments with secret", DelayedActionsConfig{Enabled: true, Store: "comments", SigningSecret: "s3cret"}, false},
+		{"file store", DelayedActionsConfig{Enabled: true, Store: "file"}, false},
+		{"unset variable", DelayedActionsConfig{SigningSecret: "${SIMILI_SIGNING_SECRET}"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			applyDefaults(cfg)
+			cfg.Defaults.DelayedActions = tt.delayed
+
+			found := false
+			for _, err := range Validate(cfg) {
+				if ve, ok := err.(ValidationError); ok && ve.Field == "defaults.delayed_actions.signing_secret" {
+					found = true
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("signing_secret error = %v, want %v", found, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetRouterThreshold(t *testing.T) {
+	cfg := &Config{
+		Repositories: []RepositoryConfig{
+			{Org: "org", Repo: "strict", RouterThreshold: 0.95},
+			{Org: "org", Repo: "plain"},
+		},
+	}
+
+	cfg.Triage.Router.Strategy = "llm"
+	if got := cfg.GetRouterThreshold("org", "strict"); got != 0.95 {
+		t.Errorf("per-repo threshold = %v, want 0.95", got)
+	}
+	if got := cfg.GetRouterThreshold("org", "plain"); got != 0.8 {
+		t.Errorf("llm default threshold = %v, want 0.8", got)
+	}
+
+	cfg.Triage.Router.Strategy = "semantic"
+	if got := cfg.GetRouterThreshold("org", "plain"); got != 0 {
+		t.Errorf("semantic default threshold = %v, want 0", got)
+	}
+
+	cfg.Triage.Router.MinConfidence = 0.6
+	if got := cfg.GetRouterThreshold("org", "plain"); got != 0.6 {
+		t.Errorf("configured threshold = %v, want 0.6", got)
+	}
+}
@@ -25,4 +25,7 @@ func expandConfigEnvVars(cfg *Config) {
 	cfg.Qdrant.APIKey = expandEnvVars(cfg.Qdrant.APIKey)
 	cfg.Embedding.Primary.APIKey = expandEnvVars(cfg.Embedding.Primary.APIKey)
 	cfg.Embedding.Fallback.APIKey = expandEnvVars(cfg.Embedding.Fallback.APIKey)
+	cfg.Rerank.Endpoint = expandEnvVars(cfg.Rerank.Endpoint)
+	cfg.Rerank.APIKey = expandEnvVars(cfg.Rerank.APIKey)
+	cfg.Defaults.DelayedActions.SigningSecret = expandEnvVars(cfg.Defaults.DelayedActions.SigningSecret)
 }
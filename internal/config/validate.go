@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
 )
 
@@ -35,6 +37,19 @@ func Validate(cfg *Config) []error {
 		errs = append(errs, ValidationError{"embedding.primary.api_key", "required"})
 	}
 
+	if cfg.Embedding.Normalize.ErrorWeight < 0 {
+		errs = append(errs, ValidationError{"embedding.normalize.error_weight", "must not be negative"})
+	}
+
+	if cfg.Embedding.Comments.Enabled {
+		if cfg.Embedding.Comments.Mode != "fold" && cfg.Embedding.Comments.Mode != "separate" {
+			errs = append(errs, ValidationError{"embedding.comments.mode", "must be 'fold' or 'separate'"})
+		}
+		if cfg.Embedding.Comments.RankBy != "reactions" && cfg.Embedding.Comments.RankBy != "maintainer" {
+			errs = append(errs, ValidationError{"embedding.comments.rank_by", "must be 'reactions' or 'maintainer'"})
+		}
+	}
+
 	// Validate defaults
 	if cfg.Defaults.SimilarityThreshold < 0 || cfg.Defaults.SimilarityThreshold > 1 {
 		errs = append(errs, ValidationError{"defaults.similarity_threshold", "must be between 0 and 1"})
@@ -44,6 +59,95 @@ func Validate(cfg *Config) []error {
 		errs = append(errs, ValidationError{"defaults.closed_issue_weight", "must be between 0 and 1"})
 	}
 
+	// Validate delayed action store
+	if store := cfg.Defaults.DelayedActions.Store; store != "" && store != "comments" && store != "file" {
+		errs = append(errs, ValidationError{"defaults.delayed_actions.store", "must be 'comments' or 'file'"})
+	}
+
+	if strings.Contains(cfg.Defaults.DelayedActions.SigningSecret, "${") {
+		errs = append(errs, ValidationError{"defaults.delayed_actions.signing_secret", "environment variable is not set"})
+	} else if cfg.Defaults.DelayedActions.Enabled && cfg.Defaults.DelayedActions.Store != "file" && cfg.Defaults.DelayedActions.SigningSecret == "" {
+		// Comment metadata decides what an action does; unsigned, anyone
+		// who can comment could forge it
+		errs = append(errs, ValidationError{"defaults.delayed_actions.signing_secret", "required with the 'comments' store"})
+	}
+
+	for i, action := range cfg.Defaults.DelayedActions.Actions {
+		switch action {
+		case "add_label", "remove_label", "comment", "lock", "assign":
+		default:
+			errs = append(errs, ValidationError{fmt.Sprintf("defaults.delayed_actions.actions[%d]", i), "must be 'add_label', 'remove_label', 'comment', 'lock' or 'assign'"})
+		}
+	}
+	if cfg.Defaults.DelayedActions.NeedsInfo.Days < 0 {
+		errs = append(errs, ValidationError{"defaults.delayed_actions.needs_info.days", "must be positive"})
+	}
+
+	// Validate delayed action authorization
+	auth := cfg.Defaults.DelayedActions.Authorization
+	for i, role := range auth.Roles {
+		if role != "author" && role != "collaborator" && role != "anyone" {
+			errs = append(errs, ValidationError{fmt.Sprintf("defaults.delayed_actions.authorization.roles[%d]", i), "must be 'author', 'collaborator' or 'anyone'"})
+		}
+	}
+	if PermissionRank(auth.MinPermission) < 0 {
+		errs = append(errs, ValidationError{"defaults.delayed_actions.authorization.min_permission", "must be 'read', 'triage', 'write', 'maintain' or 'admin'"})
+	}
+	for i, team := range auth.Teams {
+		if parts := strings.Split(team, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			errs = append(errs, ValidationError{fmt.Sprintf("defaults.delayed_actions.authorization.teams[%d]", i), "must be in format 'org/team-slug'"})
+		}
+	}
+	if auth.Quorum < 1 {
+		errs = append(errs, ValidationError{"defaults.delayed_actions.authorization.quorum", "must be at least 1"})
+	}
+
+	// Validate rerank config (only if enabled)
+	if cfg.Rerank.Enabled {
+		switch cfg.Rerank.Provider {
+		case "llm":
+			if !cfg.Triage.Enabled {
+				errs = append(errs, ValidationError{"rerank.provider", "'llm' requires triage.llm to be configured and triage enabled"})
+			}
+		case "cross_encoder":
+			if cfg.Rerank.Endpoint == "" {
+				errs = append(errs, ValidationError{"rerank.endpoint", "required for cross_encoder provider"})
+			}
+		default:
+			errs = append(errs, ValidationError{"rerank.provider", "must be 'llm' or 'cross_encoder'"})
+		}
+	}
+
+	// Validate stale sweeper config
+	if cfg.Stale.Days < 0 {
+		errs = append(errs, ValidationError{"stale.days", "must be positive"})
+	}
+	if cfg.Stale.DuplicateThreshold < 0 || cfg.Stale.DuplicateThreshold > 1 {
+		errs = append(errs, ValidationError{"stale.duplicate_threshold", "must be between 0 and 1"})
+	}
+
+	// Validate clustering config (only if enabled)
+	if cfg.Clustering.Enabled {
+		if cfg.Clustering.Threshold <= 0 || cfg.Clustering.Threshold > 1 {
+			errs = append(errs, ValidationError{"clustering.threshold", "must be between 0 and 1"})
+		}
+		switch cfg.Clustering.Canonical {
+		case "oldest_open", "most_reactions", "maintainer_label":
+		default:
+			errs = append(errs, ValidationError{"clustering.canonical", "must be 'oldest_open', 'most_reactions' or 'maintainer_label'"})
+		}
+	}
+
+	// Validate slash commands (only if enabled)
+	if cfg.Commands.Enabled {
+		if !strings.HasPrefix(cfg.Commands.Prefix, "/") || strings.ContainsAny(cfg.Commands.Prefix, " \t") {
+			errs = append(errs, ValidationError{"commands.prefix", "must start with '/' and contain no spaces"})
+		}
+		if PermissionRank(cfg.Commands.MinPermission) < 0 {
+			errs = append(errs, ValidationError{"commands.min_permission", "must be 'read', 'triage', 'write', 'maintain' or 'admin'"})
+		}
+	}
+
 	// Validate triage config (only if enabled)
 	if cfg.Triage.Enabled {
 		if cfg.Triage.LLM.Provider == "" {
@@ -60,6 +164,62 @@ func Validate(cfg *Config) []error {
 			errs = append(errs, ValidationError{"triage.classifier.min_confidence", "must be between 0 and 1"})
 		}
 
+		if cfg.Triage.Classifier.Mode != "llm" && cfg.Triage.Classifier.Mode != "knn" {
+			errs = append(errs, ValidationError{"triage.classifier.mode", "must be 'llm' or 'knn'"})
+		}
+
+		if cfg.Triage.Classifier.Examples < 0 {
+			errs = append(errs, ValidationError{"triage.classifier.examples", "must not be negative"})
+		}
+
+		for i, pattern := range cfg.Triage.Classifier.Allow {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = append(errs, ValidationError{fmt.Sprintf("triage.classifier.allow[%d]", i), "invalid pattern"})
+			}
+		}
+
+		for i, pattern := range cfg.Triage.Classifier.Deny {
+			if _, err := path.Match(pattern, ""); err != nil {
+				errs = append(errs, ValidationError{fmt.Sprintf("triage.classifier.deny[%d]", i), "invalid pattern"})
+			}
+		}
+
+		for i, label := range cfg.Triage.Classifier.Labels {
+			errs = append(errs, validateKeywordRules(fmt.Sprintf("triage.classifier.labels[%d].rules", i), label.Rules)...)
+		}
+
+		for i, group := range cfg.Triage.Classifier.Groups {
+			groupPrefix := fmt.Sprintf("triage.classifier.groups[%d]", i)
+			if len(group.Labels) == 0 {
+				errs = append(errs, ValidationError{groupPrefix + ".labels", "at least one label is required"})
+			}
+			for j, pattern := range group.Labels {
+				if _, err := path.Match(pattern, ""); err != nil {
+					errs = append(errs, ValidationError{fmt.Sprintf("%s.labels[%d]", groupPrefix, j), "invalid pattern"})
+				}
+			}
+		}
+
+		if cfg.Triage.Router.Strategy != "llm" && cfg.Triage.Router.Strategy != "semantic" {
+			errs = append(errs, ValidationError{"triage.router.strategy", "must be 'llm' or 'semantic'"})
+		}
+
+		if cfg.Triage.Router.Method != "centroid" && cfg.Triage.Router.Method != "knn" {
+			errs = append(errs, ValidationError{"triage.router.method", "must be 'centroid' or 'knn'"})
+		}
+
+		if cfg.Triage.Router.Neighbors < 0 {
+			errs = append(errs, ValidationError{"triage.router.neighbors", "must not be negative"})
+		}
+
+		if cfg.Triage.Router.Margin < 0 || cfg.Triage.Router.Margin > 1 {
+			errs = append(errs, ValidationError{"triage.router.margin", "must be between 0 and 1"})
+		}
+
+		if cfg.Triage.Router.MinConfidence < 0 || cfg.Triage.Router.MinConfidence > 1 {
+			errs = append(errs, ValidationError{"triage.router.min_confidence", "must be between 0 and 1"})
+		}
+
 		if cfg.Triage.Quality.MinScore < 0 || cfg.Triage.Quality.MinScore > 1 {
 			errs = append(errs, ValidationError{"triage.quality.min_score", "must be between 0 and 1"})
 		}
@@ -79,6 +239,9 @@ func Validate(cfg *Config) []error {
 		if repo.Repo == "" {
 			errs = append(errs, ValidationError{prefix + ".repo", "required"})
 		}
+		if repo.RouterThreshold < 0 || repo.RouterThreshold > 1 {
+			errs = append(errs, ValidationError{prefix + ".router_threshold", "must be between 0 and 1"})
+		}
 
 		// Validate transfer rules
 		for j, rule := range repo.TransferRules {
@@ -89,17 +252,125 @@ func Validate(cfg *Config) []error {
 			} else if !strings.Contains(rule.Target, "/") {
 				errs = append(errs, ValidationError{rulePrefix + ".target", "must be in format 'org/repo'"})
 			}
-
-			// At least one match condition required
-			if len(rule.Match.Labels) == 0 &&
-				len(rule.Match.TitleContains) == 0 &&
-				len(rule.Match.BodyContains) == 0 &&
-				rule.Match.Author == "" {
-				errs = append(errs, ValidationError{rulePrefix + ".match", "at least one condition required"})
+			switch rule.Strategy {
+			case "", "auto", "transfer", "copy":
+			default:
+				errs = append(errs, ValidationError{rulePrefix + ".strategy", "must be 'auto', 'transfer' or 'copy'"})
+			}
+			for from, to := range rule.LabelMap {
+				if from == "" || to == "" {
+					errs = append(errs, ValidationError{rulePrefix + ".label_map", "labels must not be empty"})
+					break
+				}
+			}
+			for _, label := range rule.AddLabels {
+				if label == "" {
+					errs = append(errs, ValidationError{rulePrefix + ".add_labels", "labels must not be empty"})
+					break
+				}
 			}
+
+			errs = append(errs, validateMatchCondition(rulePrefix+".match", &rule.Match)...)
+		}
+	}
+
+	return errs
+}
+
+// permissionLevels are GitHub's repository roles, weakest first
+var permissionLevels = []string{"read", "triage", "write", "maintain", "admin"}
+
+// PermissionRank orders a repository role against the others, or returns -1
+// for unknown roles
+func PermissionRank(permission string) int {
+	for i, p := range permissionLevels {
+		if strings.EqualFold(p, permission) {
+			return i
+		}
+	}
+	return -1
+}
+
+// authorAssociations are the author_association values reported by GitHub
+var authorAssociations = map[string]bool{
+	"OWNER":                  true,
+	"MEMBER":                 true,
+	"COLLABORATOR":           true,
+	"CONTRIBUTOR":            true,
+	"FIRST_TIME_CONTRIBUTOR": true,
+	"FIRST_TIMER":            true,
+	"MANNEQUIN":              true,
+	"NONE":                   true,
+}
+
+// validateMatchCondition checks a match condition and its nested conditions
+func validateMatchCondition(prefix string, cond *MatchCondition) []error {
+	var errs []error
+
+	// At least one match condition required
+	if cond.IsEmpty() {
+		return []error{ValidationError{prefix, "at least one condition required"}}
+	}
+
+	if cond.TitleRegex != "" {
+		if _, err := regexp.Compile(cond.TitleRegex); err != nil {
+			errs = append(errs, ValidationError{prefix + ".title_regex", fmt.Sprintf("invalid regex: %v", err)})
+		}
+	}
+	if cond.BodyRegex != "" {
+		if _, err := regexp.Compile(cond.BodyRegex); err != nil {
+			errs = append(errs, ValidationError{prefix + ".body_regex", fmt.Sprintf("invalid regex: %v", err)})
 		}
 	}
 
+	for i, assoc := range cond.AuthorAssociation {
+		if !authorAssociations[strings.ToUpper(assoc)] {
+			errs = append(errs, ValidationError{fmt.Sprintf("%s.author_association[%d]", prefix, i), fmt.Sprintf("unknown author association %q", assoc)})
+		}
+	}
+
+	errs = append(errs, validateKeywordRules(prefix+".keywords", cond.Keywords)...)
+	if cond.KeywordScore < 0 || cond.KeywordScore > 1 {
+		errs = append(errs, ValidationError{prefix + ".keyword_score", "must be between 0 and 1"})
+	}
+
+	if cond.Not != nil {
+		errs = append(errs, validateMatchCondition(prefix+".not", cond.Not)...)
+	}
+	for i := range cond.AnyOf {
+		errs = append(errs, validateMatchCondition(fmt.Sprintf("%s.any_of[%d]", prefix, i), &cond.AnyOf[i])...)
+	}
+	for i := range cond.AllOf {
+		errs = append(errs, validateMatchCondition(fmt.Sprintf("%s.all_of[%d]", prefix, i), &cond.AllOf[i])...)
+	}
+
+	return errs
+}
+
+// validateKeywordRules checks keyword rule patterns, fields and weights
+func validateKeywordRules(prefix string, rules []KeywordRule) []error {
+	var errs []error
+	for i, r := range rules {
+		rulePrefix := fmt.Sprintf("%s[%d]", prefix, i)
+
+		if r.Pattern == "" {
+			errs = append(errs, ValidationError{rulePrefix + ".pattern", "required"})
+		} else if r.Regex {
+			if _, err := regexp.Compile(r.Pattern); err != nil {
+				errs = append(errs, ValidationError{rulePrefix + ".pattern", fmt.Sprintf("invalid regex: %v", err)})
+			}
+		}
+
+		switch r.Field {
+		case "", "title", "body", "code":
+		default:
+			errs = append(errs, ValidationError{rulePrefix + ".field", "must be 'title', 'body', 'code' or empty"})
+		}
+
+		if r.Weight < -1 || r.Weight > 1 {
+			errs = append(errs, ValidationError{rulePrefix + ".weight", "must be between -1 and 1"})
+		}
+	}
 	return errs
 }
 
@@ -120,3 +391,16 @@ func (cfg *Config) GetSimilarityThreshold(org, repo string) float64 {
 	}
 	return cfg.Defaults.SimilarityThreshold
 }
+
+// GetRouterThreshold returns the router confidence needed for a repo (or
+// default). Without one, LLM suggestions need 0.8 and semantic ones only
+// their margin.
+func (cfg *Config) GetRouterThreshold(org, repo string) float64 {
+	if rc := cfg.GetRepoConfig(org, repo); rc != nil && rc.RouterThreshold > 0 {
+		return rc.RouterThreshold
+	}
+	if cfg.Triage.Router.MinConfidence == 0 && cfg.Triage.Router.Strategy != "semantic" {
+		return 0.8
+	}
+	return cfg.Triage.Router.MinConfidence
+}
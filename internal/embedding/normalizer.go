@@ -0,0 +1,298 @@
+package embedding
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultMaxLength = 6000
+
+var (
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	markdownImgRegex = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	htmlImgRegex     = regexp.MustCompile(`(?i)<img[^>]*>`)
+	codeFenceRegex   = regexp.MustCompile("(?s)```[^\n]*\n(.*?)```")
+	checkboxRegex    = regexp.MustCompile(`^\s*[-*]\s+\[[ xX]\]`)
+	errorLineRegex   = regexp.MustCompile(`(?i)(\b(error|exception|panic|fatal|traceback|segmentation fault)\b|^\s+at\s+\S+|^\s*File ".*", line \d+|goroutine \d+ \[)`)
+	markerRegex      = regexp.MustCompile(`^[#>*\-\s]*(\d+\.\s+)?(\[[ xX]\]\s*)?`)
+	signatureRegex   = regexp.MustCompile(`(?i)^(sent from my |get outlook for )`)
+)
+
+// commonTemplateLines are headings and placeholders shared by most issue templates
+var commonTemplateLines = []string{
+	"describe the bug",
+	"bug description",
+	"description",
+	"to reproduce",
+	"steps to reproduce",
+	"reproduction steps",
+	"expected behavior",
+	"expected behaviour",
+	"actual behavior",
+	"actual behaviour",
+	"screenshots",
+	"logs",
+	"environment",
+	"additional context",
+	"additional information",
+	"is your feature request related to a problem? please describe.",
+	"describe the solution you'd like",
+	"describe alternatives you've considered",
+	"_no response_",
+}
+
+// Normalizer prepares issue text for embedding by removing template
+// scaffolding and noise that would otherwise dominate the vector
+type Normalizer struct {
+	cfg           config.NormalizeConfig
+	templateLines map[string]bool
+}
+
+// NewNormalizer creates a normalizer, learning issue templates from
+// cfg.TemplateDir when configured
+func NewNormalizer(cfg *config.NormalizeConfig) *Normalizer {
+	n := &Normalizer{
+		cfg:           *cfg,
+		templateLines: make(map[string]bool),
+	}
+	if n.cfg.MaxLength == 0 {
+		n.cfg.MaxLength = defaultMaxLength
+	}
+
+	for _, line := range commonTemplateLines {
+		n.templateLines[normalizeLine(line)] = true
+	}
+
+	if cfg.Enabled && cfg.TemplateDir != "" {
+		if err := n.LearnTemplates(cfg.TemplateDir); err != nil {
+			log.Printf("Warning: failed to learn issue templates: %v", err)
+		}
+	}
+
+	return n
+}
+
+// LearnTemplates reads markdown templates and issue forms from dir and
+// records their headings and placeholder text as boilerplate
+func (n *Normalizer) LearnTemplates(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read template dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".md":
+			n.learnMarkdownTemplate(string(data))
+		case ".yml", ".yaml":
+			if entry.Name() == "config.yml" || entry.Name() == "config.yaml" {
+				continue
+			}
+			if err := n.learnIssueForm(data); err != nil {
+				log.Printf("Warning: skipping issue form %s: %v", entry.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// learnMarkdownTemplate records every line of a markdown template body
+func (n *Normalizer) learnMarkdownTemplate(content string) {
+	// Skip YAML front matter
+	if strings.HasPrefix(content, "---") {
+		if end := strings.Index(content[3:], "\n---"); end != -1 {
+			content = content[end+7:]
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		n.addTemplateLine(line)
+	}
+}
+
+// issueForm is the subset of GitHub's issue form schema we learn from
+type issueForm struct {
+	Body []struct {
+		Attributes struct {
+			Label       string `yaml:"label"`
+			Description string `yaml:"description"`
+			Placeholder string `yaml:"placeholder"`
+			Value       string `yaml:"value"`
+			Options     []any  `yaml:"options"`
+		} `yaml:"attributes"`
+	} `yaml:"body"`
+}
+
+// learnIssueForm records labels, placeholders and checkbox options of an issue form
+func (n *Normalizer) learnIssueForm(data []byte) error {
+	var form issueForm
+	if err := yaml.Unmarshal(data, &form); err != nil {
+		return err
+	}
+
+	for _, field := range form.Body {
+		attrs := field.Attributes
+		n.addTemplateLine(attrs.Label)
+		for _, text := range []string{attrs.Description, attrs.Placeholder, attrs.Value} {
+			for _, line := range strings.Split(text, "\n") {
+				n.addTemplateLine(line)
+			}
+		}
+		for _, opt := range attrs.Options {
+			switch o := opt.(type) {
+			case string:
+				n.addTemplateLine(o)
+			case map[string]any:
+				if label, ok := o["label"].(string); ok {
+					n.addTemplateLine(label)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (n *Normalizer) addTemplateLine(line string) {
+	if key := normalizeLine(line); key != "" {
+		n.templateLines[key] = true
+	}
+}
+
+// Prepare combines title and body into the text that is embedded
+func (n *Normalizer) Prepare(title, body string) string {
+	return n.PrepareWithComments(title, body, nil)
+}
+
+// PrepareWithComments combines title, body and selected comment bodies
+// into the text that is embedded
+func (n *Normalizer) PrepareWithComments(title, body string, comments []string) string {
+	if !n.cfg.Enabled {
+		text := fmt.Sprintf("Title: %s\n\nBody: %s", title, body)
+		for _, c := range comments {
+			text += "\n\nComment: " + c
+		}
+		return truncate(text, n.cfg.MaxLength)
+	}
+
+	cleaned, errors := n.Clean(body)
+
+	var cleanedComments []string
+	for _, c := range comments {
+		text, commentErrors := n.Clean(c)
+		if text != "" {
+			cleanedComments = append(cleanedComments, text)
+		}
+		errors = append(errors, commentErrors...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Title: %s\n\nBody: %s", title, cleaned))
+
+	if n.cfg.ExtractErrors && len(errors) > 0 {
+		section := strings.Join(errors, "\n")
+		for i := 0; i < n.cfg.ErrorWeight; i++ {
+			sb.WriteString("\n\nErrors: ")
+			sb.WriteString(section)
+		}
+	}
+
+	for _, c := range cleanedComments {
+		sb.WriteString("\n\nComment: ")
+		sb.WriteString(c)
+	}
+
+	return truncate(sb.String(), n.cfg.MaxLength)
+}
+
+// Clean strips template scaffolding and noise from an issue body and
+// returns the remaining text together with any extracted error lines
+func (n *Normalizer) Clean(body string) (string, []string) {
+	body = htmlCommentRegex.ReplaceAllString(body, "")
+	body = markdownImgRegex.ReplaceAllString(body, "")
+	body = htmlImgRegex.ReplaceAllString(body, "")
+
+	var errors []string
+	seen := make(map[string]bool)
+	// The pattern is matched before trimming: indented "at ..." lines are
+	// Java and Node stack frames
+	collect := func(line string) {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !seen[trimmed] && errorLineRegex.MatchString(line) {
+			seen[trimmed] = true
+			errors = append(errors, trimmed)
+		}
+	}
+
+	// Code blocks are mostly logs and config dumps: keep only their error
+	// lines, in the errors section when it is enabled and in the text
+	// otherwise, so they are never dropped from the embedding
+	body = codeFenceRegex.ReplaceAllStringFunc(body, func(block string) string {
+		var errorLines []string
+		for _, line := range strings.Split(codeFenceRegex.FindStringSubmatch(block)[1], "\n") {
+			collect(line)
+			if trimmed := strings.TrimSpace(line); trimmed != "" && errorLineRegex.MatchString(line) {
+				errorLines = append(errorLines, trimmed)
+			}
+		}
+		if n.cfg.ExtractErrors {
+			return ""
+		}
+		return strings.Join(errorLines, "\n")
+	})
+
+	var kept []string
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Everything after a signature delimiter is dropped
+		if line == "-- " || trimmed == "--" || signatureRegex.MatchString(trimmed) {
+			break
+		}
+		if checkboxRegex.MatchString(line) {
+			continue
+		}
+		if n.templateLines[normalizeLine(line)] {
+			continue
+		}
+
+		collect(line)
+		kept = append(kept, line)
+	}
+
+	return CleanText(strings.Join(kept, "\n")), errors
+}
+
+// normalizeLine reduces a line to a comparison key, ignoring markdown
+// markers, emphasis and trailing punctuation
+func normalizeLine(line string) string {
+	line = strings.TrimSpace(line)
+	line = markerRegex.ReplaceAllString(line, "")
+	line = strings.Trim(line, "*_ :")
+	return strings.Join(strings.Fields(strings.ToLower(line)), " ")
+}
+
+func truncate(text string, maxLen int) string {
+	if maxLen > 0 && len(text) > maxLen {
+		return text[:maxLen] + "..."
+	}
+	return text
+}
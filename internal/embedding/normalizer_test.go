@@ -0,0 +1,123 @@
+package embedding
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestNormalizer_Prepare_Disabled(t *testing.T) {
+	n := NewNormalizer(&config.NormalizeConfig{})
+
+	got := n.Prepare("Crash on start", "<!-- hidden -->body")
+	want := "Title: Crash on start\n\nBody: <!-- hidden -->body"
+	if got != want {
+		t.Errorf("Prepare() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizer_Clean(t *testing.T) {
+	n := NewNormalizer(&config.NormalizeConfig{Enabled: true, ExtractErrors: true})
+
+	body := "### Describe the bug\n" +
+		"<!-- A clear and concise description -->\n" +
+		"The server crashes when loading config.\n" +
+		"![screenshot](https://example.com/a.png)\n" +
+		"- [x] I have searched existing issues\n" +
+		"```\n" +
+		"starting server\n" +
+		"panic: runtime error: invalid memory address\n" +
+		"```\n" +
+		"### Additional context\n" +
+		"_No response_\n" +
+		"-- \n" +
+		"John"
+
+	cleaned, errors := n.Clean(body)
+
+	if cleaned != "The server crashes when loading config." {
+		t.Errorf("Clean() text = %q", cleaned)
+	}
+	if len(errors) != 1 || errors[0] != "panic: runtime error: invalid memory address" {
+		t.Errorf("Clean() errors = %v", errors)
+	}
+}
+
+func TestNormalizer_Clean_StackFrames(t *testing.T) {
+	n := NewNormalizer(&config.NormalizeConfig{Enabled: true, ExtractErrors: true})
+
+	body := "It fails at startup.\n" +
+		"```\n" +
+		"Exception in thread \"main\" java.lang.NullPointerException\n" +
+		"    at com.foo.Bar.run(Bar.java:10)\n" +
+		"```\n" +
+		"```\n" +
+		"Error: Cannot find module 'x'\n" +
+		"    at Object.<anonymous> (/app/index.js:3:7)\n" +
+		"```"
+
+	_, errors := n.Clean(body)
+
+	want := []string{
+		"Exception in thread \"main\" java.lang.NullPointerException",
+		"at com.foo.Bar.run(Bar.java:10)",
+		"Error: Cannot find module 'x'",
+		"at Object.<anonymous> (/app/index.js:3:7)",
+	}
+	if strings.Join(errors, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Clean() errors = %q, want %q", errors, want)
+	}
+}
+
+func TestNormalizer_Prepare_ErrorsInText(t *testing.T) {
+	// Without extract_errors the error lines of code blocks stay in the body
+	n := NewNormalizer(&config.NormalizeConfig{Enabled: true})
+
+	got := n.Prepare("Crash", "It crashes.\n```\nstarting server\npanic: runtime error: invalid memory address\n```")
+	if !strings.Contains(got, "panic: runtime error: invalid memory address") {
+		t.Errorf("Prepare() = %q, want the panic line kept", got)
+	}
+	if strings.Contains(got, "starting server") || strings.Contains(got, "Errors:") {
+		t.Errorf("Prepare() = %q, want only the error line and no errors section", got)
+	}
+}
+
+func TestNormalizer_Prepare_ErrorWeight(t *testing.T) {
+	n := NewNormalizer(&config.NormalizeConfig{Enabled: true, ExtractErrors: true, ErrorWeight: 2})
+
+	got := n.Prepare("Crash", "```\nError: boom\n```")
+	if strings.Count(got, "Errors: Error: boom") != 2 {
+		t.Errorf("Prepare() = %q, want errors section repeated twice", got)
+	}
+}
+
+func TestNormalizer_LearnTemplates(t *testing.T) {
+	dir := t.TempDir()
+
+	markdown := "---\nname: Bug\n---\n\n**Which component?**\nPlease describe the component.\n"
+	if err := os.WriteFile(filepath.Join(dir, "bug.md"), []byte(markdown), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	form := `name: Crash
+body:
+  - type: textarea
+    attributes:
+      label: Crash log
+      placeholder: Paste the log here
+`
+	if err := os.WriteFile(filepath.Join(dir, "crash.yml"), []byte(form), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	n := NewNormalizer(&config.NormalizeConfig{Enabled: true, TemplateDir: dir})
+
+	body := "**Which component?**\nPlease describe the component.\nThe scheduler.\n### Crash log\nPaste the log here"
+	cleaned, _ := n.Clean(body)
+	if cleaned != "The scheduler." {
+		t.Errorf("Clean() = %q, want %q", cleaned, "The scheduler.")
+	}
+}
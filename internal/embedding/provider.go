@@ -2,7 +2,6 @@ package embedding
 
 import (
 	"context"
-	"fmt"
 	"strings"
 )
 
@@ -13,18 +12,6 @@ type Provider interface {
 	Close() error
 }
 
-// PrepareIssueText combines title and body for embedding
-func PrepareIssueText(title, body string) string {
-	text := fmt.Sprintf("Title: %s\n\nBody: %s", title, body)
-
-	// Truncate to ~6000 chars (~1500 tokens) to stay within limits
-	if len(text) > 6000 {
-		text = text[:6000] + "..."
-	}
-
-	return text
-}
-
 // TruncateText truncates text to maxLen characters
 func TruncateText(text string, maxLen int) string {
 	if len(text) <= maxLen {
@@ -0,0 +1,116 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// duplicateOfPattern matches "Duplicate of #12", "duplicate of org/repo#12"
+// and "Duplicate of https://github.com/org/repo/issues/12"
+var duplicateOfPattern = regexp.MustCompile(`(?i)duplicate\s+of\s+(?:https?://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)|(?:([\w.-]+)/([\w.-]+))?#(\d+))`)
+
+// originalIssuePattern matches the original issue link in Simili's own duplicate comments
+var originalIssuePattern = regexp.MustCompile(`\*\*Original issue:\*\* \[#\d+[^\]]*\]\(https?://github\.com/([\w.-]+)/([\w.-]+)/issues/(\d+)\)`)
+
+// Case is a labeled evaluation example. Original is empty for issues that
+// were never marked as duplicates.
+type Case struct {
+	Issue    *models.Issue
+	Original string // "org/repo#number"
+}
+
+// IsDuplicate reports whether the case is a positive example
+func (c *Case) IsDuplicate() bool {
+	return c.Original != ""
+}
+
+// IssueKey returns the "org/repo#number" key used to compare issues
+func IssueKey(org, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", org, repo, number)
+}
+
+// ParseDuplicateOf finds the issue a text declares itself a duplicate of.
+// Short references (#12) resolve against org/repo.
+func ParseDuplicateOf(text, org, repo string) (string, bool) {
+	if m := duplicateOfPattern.FindStringSubmatch(text); m != nil {
+		if m[3] != "" {
+			n, _ := strconv.Atoi(m[3])
+			return IssueKey(m[1], m[2], n), true
+		}
+		n, _ := strconv.Atoi(m[6])
+		if m[4] != "" {
+			return IssueKey(m[4], m[5], n), true
+		}
+		return IssueKey(org, repo, n), true
+	}
+
+	if m := originalIssuePattern.FindStringSubmatch(text); m != nil {
+		n, _ := strconv.Atoi(m[3])
+		return IssueKey(m[1], m[2], n), true
+	}
+
+	return "", false
+}
+
+// BuildDataset collects issues closed as duplicates of an earlier issue as
+// positive cases, plus up to maxNegatives other issues as negative cases
+func BuildDataset(ctx context.Context, gh *github.Client, org, repo string, maxNegatives int) ([]Case, error) {
+	issues, err := gh.ListAllIssues(ctx, org, repo, "all", 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+
+	var positives, negatives []Case
+	for _, issue := range issues {
+		if !looksDuplicate(issue) {
+			if len(negatives) < maxNegatives {
+				negatives = append(negatives, Case{Issue: issue})
+			}
+			continue
+		}
+
+		original, ok := ParseDuplicateOf(issue.Body, org, repo)
+		if !ok {
+			comments, err := gh.ListComments(ctx, org, repo, issue.Number)
+			if err != nil {
+				log.Printf("Warning: failed to fetch comments for #%d: %v", issue.Number, err)
+				continue
+			}
+			for _, c := range comments {
+				if original, ok = ParseDuplicateOf(c.Body, org, repo); ok {
+					break
+				}
+			}
+		}
+
+		// Skip duplicates whose original cannot be identified or is the issue itself
+		if !ok || original == IssueKey(org, repo, issue.Number) {
+			continue
+		}
+		positives = append(positives, Case{Issue: issue, Original: original})
+	}
+
+	return append(positives, negatives...), nil
+}
+
+// looksDuplicate reports whether an issue was closed as a duplicate
+func looksDuplicate(issue *models.Issue) bool {
+	if issue.State != "closed" {
+		return false
+	}
+	if issue.StateReason == github.CloseReasonDuplicate {
+		return true
+	}
+	for _, l := range issue.Labels {
+		if l == "duplicate" {
+			return true
+		}
+	}
+	return false
+}
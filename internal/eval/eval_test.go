@@ -0,0 +1,128 @@
+package eval
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestParseDuplicateOf(t *testing.T) {
+	tests := []struct {
+		text   string
+		want   string
+		wantOK bool
+	}{
+		{"Duplicate of #12", "org/app#12", true},
+		{"Closing. duplicate of other/lib#7", "other/lib#7", true},
+		{"Duplicate of https://github.com/org/cli/issues/3", "org/cli#3", true},
+		{"**Original issue:** [#5 - Crash](https://github.com/org/app/issues/5)", "org/app#5", true},
+		{"Not related to #12", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseDuplicateOf(tt.text, "org", "app")
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("ParseDuplicateOf(%q) = %q, %v; want %q, %v", tt.text, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestSweep(t *testing.T) {
+	result := func(number int, score float64) vectordb.SearchResult {
+		return vectordb.SearchResult{Issue: models.Issue{Org: "org", Repo: "app", Number: number}, Score: score}
+	}
+
+	rankings := []Ranking{
+		// Correct original ranked first
+		{Case: Case{Original: "org/app#1"}, Results: []vectordb.SearchResult{result(1, 0.95), result(2, 0.80)}},
+		// Correct original ranked second
+		{Case: Case{Original: "org/app#3"}, Results: []vectordb.SearchResult{result(4, 0.90), result(3, 0.85)}},
+		// Non-duplicate with a close neighbor
+		{Case: Case{}, Results: []vectordb.SearchResult{result(5, 0.88)}},
+	}
+
+	metrics := Sweep(rankings, []float64{0.80, 0.92})
+
+	low := metrics[0]
+	if low.TruePositives != 1 || low.FalsePositives != 2 || low.FalseNegatives != 1 {
+		t.Errorf("at 0.80 got TP=%d FP=%d FN=%d, want 1/2/1", low.TruePositives, low.FalsePositives, low.FalseNegatives)
+	}
+	if math.Abs(low.MRR-0.75) > 1e-9 {
+		t.Errorf("at 0.80 MRR = %v, want 0.75", low.MRR)
+	}
+
+	high := metrics[1]
+	if high.Precision != 1 || high.Recall != 0.5 {
+		t.Errorf("at 0.92 precision=%v recall=%v, want 1/0.5", high.Precision, high.Recall)
+	}
+
+	if best := Best(metrics); best.Threshold != 0.92 {
+		t.Errorf("Best() threshold = %v, want 0.92", best.Threshold)
+	}
+}
+
+func TestThresholds(t *testing.T) {
+	got := Thresholds(0.8, 0.9, 0.05)
+	want := []float64{0.8, 0.85, 0.9}
+	if len(got) != len(want) {
+		t.Fatalf("Thresholds() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Thresholds()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestScoreLabels(t *testing.T) {
+	labels := []config.LabelConfig{{Name: "bug"}, {Name: "enhancement"}}
+	predictions := []LabelPrediction{
+		{Actual: []string{"bug"}, Predicted: map[string]float64{"bug": 0.9}},
+		{Actual: []string{"bug"}, Predicted: map[string]float64{"bug": 0.6}},
+		{Actual: []string{"enhancement"}, Predicted: map[string]float64{"bug": 0.8}},
+		{Actual: nil, Predicted: map[string]float64{"enhancement": 0.4}},
+	}
+
+	report := ScoreLabels(predictions, labels, 0.7)
+
+	bug := report.Labels[0]
+	if bug.TruePositives != 1 || bug.FalsePositives != 1 || bug.FalseNegatives != 1 {
+		t.Errorf("bug TP=%d FP=%d FN=%d, want 1/1/1", bug.TruePositives, bug.FalsePositives, bug.FalseNegatives)
+	}
+	if bug.SuggestedMinConfidence > 0.6 {
+		t.Errorf("bug suggested min_confidence = %v, want <= 0.6 to recover the 0.6 match", bug.SuggestedMinConfidence)
+	}
+
+	if got := report.Confusion["enhancement"]["bug"]; got != 1 {
+		t.Errorf("confusion[enhancement][bug] = %d, want 1", got)
+	}
+	if got := report.Confusion["bug"][noLabel]; got != 1 {
+		t.Errorf("confusion[bug][none] = %d, want 1", got)
+	}
+}
+
+func TestTriaged(t *testing.T) {
+	known := map[string]bool{"bug": true}
+
+	tests := []struct {
+		name  string
+		issue *models.Issue
+		want  bool
+	}{
+		{name: "closed without labels", issue: &models.Issue{State: "closed"}, want: true},
+		{name: "open with known label", issue: &models.Issue{State: "open", Labels: []string{"bug"}}, want: true},
+		{name: "open with unknown label", issue: &models.Issue{State: "open", Labels: []string{"stale"}}, want: false},
+		{name: "open without labels", issue: &models.Issue{State: "open"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := triaged(tt.issue, known); got != tt.want {
+				t.Errorf("triaged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,294 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"sort"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// noLabel marks the absence of a label in the confusion matrix
+const noLabel = "(none)"
+
+// LabelPrediction is the classifier output for one issue next to the labels
+// maintainers applied
+type LabelPrediction struct {
+	Issue     *models.Issue
+	Actual    []string
+	Predicted map[string]float64 // label -> confidence
+}
+
+// LabelMetrics summarizes classifier agreement for one label
+type LabelMetrics struct {
+	Label                  string  `json:"label"`
+	Support                int     `json:"support"` // issues carrying the label
+	TruePositives          int     `json:"true_positives"`
+	FalsePositives         int     `json:"false_positives"`
+	FalseNegatives         int     `json:"false_negatives"`
+	Precision              float64 `json:"precision"`
+	Recall                 float64 `json:"recall"`
+	F1                     float64 `json:"f1"`
+	SuggestedMinConfidence float64 `json:"suggested_min_confidence"`
+	SuggestedF1            float64 `json:"suggested_f1"`
+}
+
+// LabelReport contains classifier evaluation results for a repository
+type LabelReport struct {
+	Repo          string                    `json:"repo"`
+	Issues        int                       `json:"issues"`
+	MinConfidence float64                   `json:"min_confidence"`
+	Labels        []LabelMetrics            `json:"labels"`
+	Confusion     map[string]map[string]int `json:"confusion"` // actual -> predicted -> count, for disagreements
+}
+
+// EvaluateClassifier runs the classifier on the most recent sample triaged
+// issues of a repository with their labels hidden and compares the
+// suggestions with the labels maintainers applied. Only labels the
+// classifier may suggest are compared, including the repository's own when
+// sync_labels is set. examples may be nil.
+func EvaluateClassifier(ctx context.Context, gh *github.Client, provider llm.Provider, examples triage.ExampleSource, cfg *config.ClassifierConfig, fullRepo string, sample int) (*LabelReport, error) {
+	org, repo, err := github.ParseRepo(fullRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	// Score every label so suggested thresholds can go below the configured one
+	scoring := *cfg
+	scoring.MinConfidence = 0
+	classifier := triage.NewClassifier(provider, &scoring)
+	if examples != nil {
+		classifier = triage.NewClassifierWithExamples(provider, &scoring, examples)
+	}
+	if cfg.SyncLabels {
+		classifier.SyncLabels(gh)
+	}
+
+	labels := classifier.Labels(ctx, org, repo)
+	known := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		known[l.Name] = true
+	}
+
+	issues, err := recentIssues(ctx, gh, org, repo, sample, func(issue *models.Issue) bool {
+		return triaged(issue, known)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := make([]LabelPrediction, 0, len(issues))
+	for i, issue := range issues {
+		log.Printf("Classifying %d/%d: #%d", i+1, len(issues), issue.Number)
+
+		var actual []string
+		for _, l := range issue.Labels {
+			if known[l] {
+				actual = append(actual, l)
+			}
+		}
+
+		hidden := *issue
+		hidden.Labels = nil
+		results, err := classifier.Classify(ctx, &hidden)
+		if err != nil {
+			return nil, fmt.Errorf("failed to classify #%d: %w", issue.Number, err)
+		}
+
+		predicted := make(map[string]float64, len(results))
+		for _, r := range results {
+			predicted[r.Label] = r.Confidence
+		}
+		predictions = append(predictions, LabelPrediction{Issue: issue, Actual: actual, Predicted: predicted})
+	}
+
+	report := ScoreLabels(predictions, labels, cfg.MinConfidence)
+	report.Repo = fullRepo
+	return report, nil
+}
+
+// ScoreLabels computes per-label metrics at minConfidence, a suggested
+// min_confidence per label and the confusion between labels
+func ScoreLabels(predictions []LabelPrediction, labels []config.LabelConfig, minConfidence float64) *LabelReport {
+	report := &LabelReport{
+		Issues:        len(predictions),
+		MinConfidence: minConfidence,
+		Confusion:     make(map[string]map[string]int),
+	}
+
+	for _, l := range labels {
+		m := scoreLabel(predictions, l.Name, minConfidence)
+		m.Label = l.Name
+
+		// Sweep thresholds for the best F1, preferring higher confidence on ties
+		for t := 0.05; t < 1.0; t += 0.05 {
+			candidate := scoreLabel(predictions, l.Name, t)
+			if candidate.F1 >= m.SuggestedF1 && candidate.F1 > 0 {
+				m.SuggestedF1 = candidate.F1
+				m.SuggestedMinConfidence = math.Round(t*100) / 100
+			}
+		}
+		report.Labels = append(report.Labels, m)
+	}
+
+	for _, p := range predictions {
+		actual := toSet(p.Actual)
+		var predicted []string
+		for label, conf := range p.Predicted {
+			if conf >= minConfidence {
+				predicted = append(predicted, label)
+			}
+		}
+		predictedSet := toSet(predicted)
+
+		var missed, extra []string
+		for _, a := range p.Actual {
+			if !predictedSet[a] {
+				missed = append(missed, a)
+			}
+		}
+		for _, pr := range predicted {
+			if !actual[pr] {
+				extra = append(extra, pr)
+			}
+		}
+		if len(missed) == 0 {
+			missed = []string{noLabel}
+		}
+		if len(extra) == 0 {
+			extra = []string{noLabel}
+		}
+		for _, a := range missed {
+			for _, pr := range extra {
+				if a == noLabel && pr == noLabel {
+					continue
+				}
+				if report.Confusion[a] == nil {
+					report.Confusion[a] = make(map[string]int)
+				}
+				report.Confusion[a][pr]++
+			}
+		}
+	}
+
+	return report
+}
+
+// scoreLabel computes agreement for one label at a confidence threshold
+func scoreLabel(predictions []LabelPrediction, label string, threshold float64) LabelMetrics {
+	var m LabelMetrics
+	for _, p := range predictions {
+		actual := toSet(p.Actual)[label]
+		conf, ok := p.Predicted[label]
+		predicted := ok && conf >= threshold
+
+		if actual {
+			m.Support++
+		}
+		switch {
+		case actual && predicted:
+			m.TruePositives++
+		case predicted:
+			m.FalsePositives++
+		case actual:
+			m.FalseNegatives++
+		}
+	}
+
+	if n := m.TruePositives + m.FalsePositives; n > 0 {
+		m.Precision = float64(m.TruePositives) / float64(n)
+	}
+	if m.Support > 0 {
+		m.Recall = float64(m.TruePositives) / float64(m.Support)
+	}
+	if m.Precision+m.Recall > 0 {
+		m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+	}
+	return m
+}
+
+// WriteLabelTable writes a human-readable classifier report
+func WriteLabelTable(w io.Writer, r *LabelReport) {
+	fmt.Fprintf(w, "\n%s (%d issues, min_confidence %.2f)\n", r.Repo, r.Issues, r.MinConfidence)
+	fmt.Fprintf(w, "%-24s %-8s %-10s %-10s %-10s %-10s\n", "label", "support", "precision", "recall", "f1", "suggested")
+	for _, m := range r.Labels {
+		fmt.Fprintf(w, "%-24s %-8d %-10.3f %-10.3f %-10.3f %-10.2f\n",
+			m.Label, m.Support, m.Precision, m.Recall, m.F1, m.SuggestedMinConfidence)
+	}
+
+	if len(r.Confusion) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\nDisagreements (maintainer label -> classifier label):")
+	actuals := make([]string, 0, len(r.Confusion))
+	for a := range r.Confusion {
+		actuals = append(actuals, a)
+	}
+	sort.Strings(actuals)
+	for _, a := range actuals {
+		predicted := make([]string, 0, len(r.Confusion[a]))
+		for p := range r.Confusion[a] {
+			predicted = append(predicted, p)
+		}
+		sort.Strings(predicted)
+		for _, p := range predicted {
+			fmt.Fprintf(w, "  %-24s -> %-24s %d\n", a, p, r.Confusion[a][p])
+		}
+	}
+}
+
+// toSet converts a slice to a set
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, i := range items {
+		set[i] = true
+	}
+	return set
+}
+
+// triaged reports whether an issue's labels can serve as ground truth:
+// closed issues have been looked at even when left unlabeled, while an open
+// issue without any known label may simply not have been triaged yet
+func triaged(issue *models.Issue, known map[string]bool) bool {
+	if issue.State == "closed" {
+		return true
+	}
+	for _, l := range issue.Labels {
+		if known[l] {
+			return true
+		}
+	}
+	return false
+}
+
+// recentIssues fetches up to n of the most recently updated issues accepted by keep
+func recentIssues(ctx context.Context, gh *github.Client, org, repo string, n int, keep func(*models.Issue) bool) ([]*models.Issue, error) {
+	var issues []*models.Issue
+	for page := 1; len(issues) < n; page++ {
+		batch, more, err := gh.ListIssuesPage(ctx, org, repo, github.ListOptions{State: "all", PerPage: 100, Page: page})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		}
+		for _, issue := range batch {
+			if keep(issue) {
+				issues = append(issues, issue)
+			}
+		}
+		if !more {
+			break
+		}
+	}
+
+	if len(issues) > n {
+		issues = issues[:n]
+	}
+	return issues, nil
+}
@@ -0,0 +1,104 @@
+package eval
+
+import "github.com/Kavirubc/gh-simili/internal/vectordb"
+
+// Ranking holds the replayed search results for one case
+type Ranking struct {
+	Case    Case
+	Results []vectordb.SearchResult // sorted by descending score
+}
+
+// ThresholdMetrics summarizes duplicate detection at one threshold. A
+// prediction is the top result scoring at or above the threshold.
+type ThresholdMetrics struct {
+	Threshold      float64 `json:"threshold"`
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	FalseNegatives int     `json:"false_negatives"`
+	Precision      float64 `json:"precision"`
+	Recall         float64 `json:"recall"`
+	F1             float64 `json:"f1"`
+	MRR            float64 `json:"mrr"` // mean reciprocal rank of the original among results above the threshold
+}
+
+// Sweep computes metrics for each threshold
+func Sweep(rankings []Ranking, thresholds []float64) []ThresholdMetrics {
+	metrics := make([]ThresholdMetrics, len(thresholds))
+	for i, t := range thresholds {
+		metrics[i] = evaluate(rankings, t)
+	}
+	return metrics
+}
+
+// Best returns the metrics with the highest F1, preferring higher thresholds on ties
+func Best(metrics []ThresholdMetrics) *ThresholdMetrics {
+	var best *ThresholdMetrics
+	for i := range metrics {
+		m := &metrics[i]
+		if best == nil || m.F1 > best.F1 || (m.F1 == best.F1 && m.Threshold > best.Threshold) {
+			best = m
+		}
+	}
+	return best
+}
+
+// evaluate computes metrics at a single threshold
+func evaluate(rankings []Ranking, threshold float64) ThresholdMetrics {
+	m := ThresholdMetrics{Threshold: threshold}
+	positives := 0
+	var reciprocalRanks float64
+
+	for _, r := range rankings {
+		predicted := ""
+		if len(r.Results) > 0 && r.Results[0].Score >= threshold {
+			predicted = resultKey(&r.Results[0])
+		}
+
+		if !r.Case.IsDuplicate() {
+			if predicted != "" {
+				m.FalsePositives++
+			}
+			continue
+		}
+
+		positives++
+		switch {
+		case predicted == r.Case.Original:
+			m.TruePositives++
+		case predicted != "":
+			// Wrong original: a false alarm and a missed duplicate
+			m.FalsePositives++
+			m.FalseNegatives++
+		default:
+			m.FalseNegatives++
+		}
+
+		for rank, res := range r.Results {
+			if res.Score < threshold {
+				break
+			}
+			if resultKey(&res) == r.Case.Original {
+				reciprocalRanks += 1 / float64(rank+1)
+				break
+			}
+		}
+	}
+
+	if predictions := m.TruePositives + m.FalsePositives; predictions > 0 {
+		m.Precision = float64(m.TruePositives) / float64(predictions)
+	}
+	if positives > 0 {
+		m.Recall = float64(m.TruePositives) / float64(positives)
+		m.MRR = reciprocalRanks / float64(positives)
+	}
+	if m.Precision+m.Recall > 0 {
+		m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+	}
+
+	return m
+}
+
+// resultKey returns the issue key of a search result
+func resultKey(r *vectordb.SearchResult) string {
+	return IssueKey(r.Issue.Org, r.Issue.Repo, r.Issue.Number)
+}
@@ -0,0 +1,67 @@
+package eval
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes reports as indented JSON
+func WriteJSON(w io.Writer, reports []*RepoReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(reports)
+}
+
+// WriteCSV writes one row per repository and threshold
+func WriteCSV(w io.Writer, reports []*RepoReport) error {
+	cw := csv.NewWriter(w)
+	header := []string{"repo", "threshold", "true_positives", "false_positives", "false_negatives", "precision", "recall", "f1", "mrr"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, r := range reports {
+		for _, m := range r.Thresholds {
+			row := []string{
+				r.Repo,
+				formatFloat(m.Threshold),
+				strconv.Itoa(m.TruePositives),
+				strconv.Itoa(m.FalsePositives),
+				strconv.Itoa(m.FalseNegatives),
+				formatFloat(m.Precision),
+				formatFloat(m.Recall),
+				formatFloat(m.F1),
+				formatFloat(m.MRR),
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTable writes a human-readable summary
+func WriteTable(w io.Writer, reports []*RepoReport) {
+	for _, r := range reports {
+		fmt.Fprintf(w, "\n%s (%d duplicates, %d other issues)\n", r.Repo, r.Positives, r.Negatives)
+		fmt.Fprintf(w, "%-10s %-10s %-10s %-10s %-10s\n", "threshold", "precision", "recall", "f1", "mrr")
+		for _, m := range r.Thresholds {
+			fmt.Fprintf(w, "%-10.2f %-10.3f %-10.3f %-10.3f %-10.3f\n", m.Threshold, m.Precision, m.Recall, m.F1, m.MRR)
+		}
+		if r.Best != nil {
+			fmt.Fprintf(w, "Best F1 %.3f at threshold %.2f (configured: similarity %.2f, auto-close %.2f)\n",
+				r.Best.F1, r.Best.Threshold, r.SimilarityThreshold, r.AutoCloseThreshold)
+		}
+	}
+}
+
+// formatFloat renders a metric with fixed precision
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', 4, 64)
+}
@@ -0,0 +1,137 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// RouteSuggestion is the router's decision for one issue
+type RouteSuggestion struct {
+	Number     int     `json:"number"`
+	Title      string  `json:"title"`
+	URL        string  `json:"url"`
+	Target     string  `json:"target"` // repository the router picked
+	Confidence float64 `json:"confidence"`
+	Reason     string  `json:"reason"`
+	Move       bool    `json:"move"` // target differs and clears the repo's router threshold
+}
+
+// RouteReport lists the router's decisions for a repository
+type RouteReport struct {
+	Repo        string            `json:"repo"`
+	Since       time.Time         `json:"since"`
+	Issues      int               `json:"issues"`
+	Threshold   float64           `json:"threshold"`
+	Suggestions []RouteSuggestion `json:"suggestions"` // issues the router would move, or wanted to
+	Errors      int               `json:"errors"`
+}
+
+// RouteIssues runs the router over open issues created since the given time
+// without transferring anything
+func RouteIssues(ctx context.Context, gh *github.Client, router triage.IssueRouter, cfg *config.Config, fullRepo string, since time.Time) (*RouteReport, error) {
+	org, repo, err := github.ParseRepo(fullRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := issuesCreatedSince(ctx, gh, org, repo, since)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RouteReport{
+		Repo:      fullRepo,
+		Since:     since,
+		Issues:    len(issues),
+		Threshold: cfg.GetRouterThreshold(org, repo),
+	}
+
+	for i, issue := range issues {
+		log.Printf("Routing %d/%d: #%d", i+1, len(issues), issue.Number)
+
+		result, err := router.Route(ctx, issue)
+		if err != nil {
+			log.Printf("Warning: failed to route #%d: %v", issue.Number, err)
+			report.Errors++
+			continue
+		}
+		if result == nil || result.TargetRepo == "" || result.TargetRepo == issue.FullRepo() {
+			continue
+		}
+
+		report.Suggestions = append(report.Suggestions, RouteSuggestion{
+			Number:     issue.Number,
+			Title:      issue.Title,
+			URL:        issue.URL,
+			Target:     result.TargetRepo,
+			Confidence: result.Confidence,
+			Reason:     result.Reason,
+			Move:       triage.SuggestedTarget(cfg, issue, result) != "",
+		})
+	}
+
+	return report, nil
+}
+
+// WriteRouteTable writes a routing report as a plain-text table
+func WriteRouteTable(w io.Writer, r *RouteReport) {
+	moves := 0
+	for _, s := range r.Suggestions {
+		if s.Move {
+			moves++
+		}
+	}
+
+	fmt.Fprintf(w, "\n%s: %d of %d issues since %s would move (threshold %.2f)\n",
+		r.Repo, moves, r.Issues, r.Since.Format("2006-01-02"), r.Threshold)
+	if len(r.Suggestions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%-8s %-40s %-30s %-10s %-6s %s\n", "issue", "title", "target", "conf", "move", "reason")
+	for _, s := range r.Suggestions {
+		move := "no"
+		if s.Move {
+			move = "yes"
+		}
+		fmt.Fprintf(w, "#%-7d %-40s %-30s %-10.2f %-6s %s\n",
+			s.Number, truncate(s.Title, 40), s.Target, s.Confidence, move, s.Reason)
+	}
+}
+
+// issuesCreatedSince fetches open issues created after since
+func issuesCreatedSince(ctx context.Context, gh *github.Client, org, repo string, since time.Time) ([]*models.Issue, error) {
+	var issues []*models.Issue
+	for page := 1; ; page++ {
+		// Issues are listed by update time, so anything created since was also updated since
+		batch, more, err := gh.ListIssuesPage(ctx, org, repo, github.ListOptions{State: "open", PerPage: 100, Page: page, Since: since})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues: %w", err)
+		}
+		for _, issue := range batch {
+			if !issue.CreatedAt.Before(since) {
+				issues = append(issues, issue)
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return issues, nil
+}
+
+// truncate shortens s to maxLen characters with an ellipsis
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
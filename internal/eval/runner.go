@@ -0,0 +1,165 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/embedding"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+)
+
+// embedBatchSize is the number of case texts embedded per request
+const embedBatchSize = 50
+
+// Options configures an evaluation run
+type Options struct {
+	Thresholds   []float64
+	MaxNegatives int // non-duplicate issues replayed to measure false positives
+	Limit        int // search results considered per case
+}
+
+// RepoReport contains the evaluation results for one repository
+type RepoReport struct {
+	Repo                string             `json:"repo"`
+	Positives           int                `json:"positives"`
+	Negatives           int                `json:"negatives"`
+	SimilarityThreshold float64            `json:"similarity_threshold"`
+	AutoCloseThreshold  float64            `json:"auto_close_threshold"`
+	Best                *ThresholdMetrics  `json:"best,omitempty"`
+	Thresholds          []ThresholdMetrics `json:"thresholds"`
+}
+
+// Runner replays labeled duplicates against the index
+type Runner struct {
+	cfg        *config.Config
+	gh         *github.Client
+	embedder   *embedding.FallbackProvider
+	normalizer *embedding.Normalizer
+	vdb        *vectordb.Client
+}
+
+// NewRunner creates a new evaluation runner
+func NewRunner(cfg *config.Config) (*Runner, error) {
+	gh, err := github.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	embedder, err := embedding.NewFallbackProvider(&cfg.Embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	vdb, err := vectordb.NewClient(&cfg.Qdrant)
+	if err != nil {
+		embedder.Close()
+		return nil, err
+	}
+
+	return &Runner{
+		cfg:        cfg,
+		gh:         gh,
+		embedder:   embedder,
+		normalizer: embedding.NewNormalizer(&cfg.Embedding.Normalize),
+		vdb:        vdb,
+	}, nil
+}
+
+// Close releases resources
+func (r *Runner) Close() error {
+	r.embedder.Close()
+	return r.vdb.Close()
+}
+
+// EvaluateRepo builds the dataset for a repository and sweeps thresholds
+func (r *Runner) EvaluateRepo(ctx context.Context, fullRepo string, opts Options) (*RepoReport, error) {
+	org, repo, err := github.ParseRepo(fullRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Building dataset for %s...", fullRepo)
+	cases, err := BuildDataset(ctx, r.gh, org, repo, opts.MaxNegatives)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RepoReport{
+		Repo:                fullRepo,
+		SimilarityThreshold: r.cfg.GetSimilarityThreshold(org, repo),
+		AutoCloseThreshold:  r.cfg.Triage.Duplicate.AutoCloseThreshold,
+	}
+	for _, c := range cases {
+		if c.IsDuplicate() {
+			report.Positives++
+		} else {
+			report.Negatives++
+		}
+	}
+	log.Printf("Replaying %d duplicates and %d other issues", report.Positives, report.Negatives)
+
+	rankings, err := r.replay(ctx, cases, opts.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Thresholds = Sweep(rankings, opts.Thresholds)
+	report.Best = Best(report.Thresholds)
+
+	return report, nil
+}
+
+// replay searches the index as it was when each case was created
+func (r *Runner) replay(ctx context.Context, cases []Case, limit int) ([]Ranking, error) {
+	rankings := make([]Ranking, 0, len(cases))
+
+	for start := 0; start < len(cases); start += embedBatchSize {
+		end := start + embedBatchSize
+		if end > len(cases) {
+			end = len(cases)
+		}
+		batch := cases[start:end]
+
+		texts := make([]string, len(batch))
+		for i, c := range batch {
+			texts[i] = r.normalizer.Prepare(c.Issue.Title, c.Issue.Body)
+		}
+
+		vectors, err := r.embedder.EmbedBatch(ctx, texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate embeddings: %w", err)
+		}
+
+		for i, c := range batch {
+			collection := vectordb.CollectionName(c.Issue.Org)
+			results, err := r.vdb.SearchBefore(ctx, collection, vectors[i], limit, c.Issue.CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			rankings = append(rankings, Ranking{Case: c, Results: results})
+		}
+	}
+
+	return rankings, nil
+}
+
+// Thresholds returns the thresholds from..to (inclusive) in steps of step
+func Thresholds(from, to, step float64) []float64 {
+	if step <= 0 || from > to {
+		return nil
+	}
+
+	var thresholds []float64
+	for i := 0; ; i++ {
+		t := math.Round((from+float64(i)*step)*1000) / 1000
+		if t > to+1e-9 {
+			break
+		}
+		thresholds = append(thresholds, t)
+	}
+	return thresholds
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -74,35 +75,91 @@ func ParseRepo(fullRepo string) (string, string, error) {
 	return parts[0], parts[1], nil
 }
 
+// ParseIssueURL splits "https://github.com/owner/repo/issues/123" into owner, repo and number
+func ParseIssueURL(issueURL string) (string, string, int, error) {
+	path := issueURL
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) != 5 || parts[3] != "issues" {
+		return "", "", 0, fmt.Errorf("invalid issue URL: %s", issueURL)
+	}
+
+	number, err := strconv.Atoi(parts[4])
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid issue number in URL: %s", issueURL)
+	}
+
+	return parts[1], parts[2], number, nil
+}
+
 // Issue represents a GitHub issue from the API
 type Issue struct {
-	Number    int       `json:"number"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	State     string    `json:"state"`
-	HTMLURL   string    `json:"html_url"`
-	User      User      `json:"user"`
-	Labels    []Label   `json:"labels"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Number            int              `json:"number"`
+	NodeID            string           `json:"node_id"`
+	Title             string           `json:"title"`
+	Body              string           `json:"body"`
+	State             string           `json:"state"`
+	StateReason       string           `json:"state_reason"`
+	HTMLURL           string           `json:"html_url"`
+	User              User             `json:"user"`
+	AuthorAssociation string           `json:"author_association"`
+	Type              *IssueType       `json:"type"`
+	Labels            []Label          `json:"labels"`
+	Reactions         ReactionsSummary `json:"reactions"`
+	PullRequest       *struct{}        `json:"pull_request"` // set when the issue is a pull request
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// IssueType represents an organization-defined issue type
+type IssueType struct {
+	Name string `json:"name"`
+}
+
+// TypeName returns the issue type name, or "" when the issue has no type
+func (t *IssueType) TypeName() string {
+	if t == nil {
+		return ""
+	}
+	return t.Name
 }
 
 // User represents a GitHub user
 type User struct {
 	Login string `json:"login"`
+	Type  string `json:"type,omitempty"` // "User" or "Bot"
 }
 
 // Label represents a GitHub label
 type Label struct {
-	Name string `json:"name"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
 }
 
 // Comment represents a GitHub comment
 type Comment struct {
-	ID        int       `json:"id"`
-	Body      string    `json:"body"`
-	User      User      `json:"user"`
-	CreatedAt time.Time `json:"created_at"`
+	ID                int              `json:"id"`
+	Body              string           `json:"body"`
+	User              User             `json:"user"`
+	AuthorAssociation string           `json:"author_association"`
+	Reactions         ReactionsSummary `json:"reactions"`
+	CreatedAt         time.Time        `json:"created_at"`
+}
+
+// ReactionsSummary contains the reaction rollup returned with comments
+type ReactionsSummary struct {
+	TotalCount int `json:"total_count"`
+}
+
+// IsMaintainer reports whether the comment author has write access to the repo
+func (c *Comment) IsMaintainer() bool {
+	switch c.AuthorAssociation {
+	case "OWNER", "MEMBER", "COLLABORATOR":
+		return true
+	}
+	return false
 }
 
 // ToModel converts API Issue to models.Issue
@@ -113,18 +170,32 @@ func (i *Issue) ToModel(org, repo string) *models.Issue {
 	}
 
 	return &models.Issue{
-		Org:       org,
-		Repo:      repo,
-		Number:    i.Number,
-		Title:     i.Title,
-		Body:      i.Body,
-		State:     i.State,
-		Labels:    labels,
-		Author:    i.User.Login,
-		URL:       i.HTMLURL,
-		CreatedAt: i.CreatedAt,
-		UpdatedAt: i.UpdatedAt,
+		Org:               org,
+		Repo:              repo,
+		Number:            i.Number,
+		Title:             i.Title,
+		Body:              i.Body,
+		State:             i.State,
+		StateReason:       i.StateReason,
+		Labels:            labels,
+		Author:            i.User.Login,
+		AuthorAssociation: i.AuthorAssociation,
+		Type:              i.Type.TypeName(),
+		URL:               i.HTMLURL,
+		Reactions:         i.Reactions.TotalCount,
+		CreatedAt:         i.CreatedAt,
+		UpdatedAt:         i.UpdatedAt,
+	}
+}
+
+// AuthenticatedLogin returns the login of the token's user. GitHub App
+// installation and Actions tokens have no user and return an error.
+func (c *Client) AuthenticatedLogin(ctx context.Context) (string, error) {
+	var user User
+	if err := c.rest.Get("user", &user); err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
 	}
+	return user.Login, nil
 }
 
 // RepoExists checks if a repository exists
@@ -13,7 +13,7 @@ const botSignature = "Simili"
 
 // ListComments fetches comments on an issue
 func (c *Client) ListComments(ctx context.Context, org, repo string, number int) ([]Comment, error) {
-	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/comments", org, repo, number)
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/comments?per_page=100", org, repo, number)
 
 	var comments []Comment
 	if err := c.rest.Get(endpoint, &comments); err != nil {
@@ -40,6 +40,23 @@ func (c *Client) PostComment(ctx context.Context, org, repo string, number int,
 	return nil
 }
 
+// UpdateComment replaces the body of an existing comment
+func (c *Client) UpdateComment(ctx context.Context, org, repo string, commentID int, body string) error {
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%d", org, repo, commentID)
+
+	payload := map[string]string{"body": body}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rest.Patch(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
+		return fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return nil
+}
+
 // ShouldSkipComment checks if bot recently commented (within cooldown period)
 func (c *Client) ShouldSkipComment(ctx context.Context, org, repo string, number int, cooldownHours int) (bool, error) {
 	comments, err := c.ListComments(ctx, org, repo, number)
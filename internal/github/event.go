@@ -19,13 +19,15 @@ type Event struct {
 
 // EventIssue represents issue data in an event
 type EventIssue struct {
-	Number  int          `json:"number"`
-	Title   string       `json:"title"`
-	Body    string       `json:"body"`
-	State   string       `json:"state"`
-	HTMLURL string       `json:"html_url"`
-	User    *EventSender `json:"user"`
-	Labels  []Label      `json:"labels"`
+	Number            int          `json:"number"`
+	Title             string       `json:"title"`
+	Body              string       `json:"body"`
+	State             string       `json:"state"`
+	HTMLURL           string       `json:"html_url"`
+	User              *EventSender `json:"user"`
+	AuthorAssociation string       `json:"author_association"`
+	Type              *IssueType   `json:"type"`
+	Labels            []Label      `json:"labels"`
 }
 
 // EventRepo represents repository data in an event
@@ -40,6 +42,7 @@ type EventRepo struct {
 // EventSender represents the user who triggered the event
 type EventSender struct {
 	Login string `json:"login"`
+	Type  string `json:"type,omitempty"` // "User" or "Bot"
 }
 
 // ParseEventFile reads and parses a GitHub event JSON file
@@ -74,15 +77,17 @@ func (e *Event) ToIssue() *models.Issue {
 	}
 
 	return &models.Issue{
-		Org:    e.Repo.Owner.Login,
-		Repo:   e.Repo.Name,
-		Number: e.Issue.Number,
-		Title:  e.Issue.Title,
-		Body:   e.Issue.Body,
-		State:  e.Issue.State,
-		Labels: labels,
-		Author: author,
-		URL:    e.Issue.HTMLURL,
+		Org:               e.Repo.Owner.Login,
+		Repo:              e.Repo.Name,
+		Number:            e.Issue.Number,
+		Title:             e.Issue.Title,
+		Body:              e.Issue.Body,
+		State:             e.Issue.State,
+		Labels:            labels,
+		Author:            author,
+		AuthorAssociation: e.Issue.AuthorAssociation,
+		Type:              e.Issue.Type.TypeName(),
+		URL:               e.Issue.HTMLURL,
 	}
 }
 
@@ -123,7 +128,23 @@ func (e *Event) IsIssueCommentEvent() bool {
 
 // EventComment represents comment data in an event
 type EventComment struct {
-	ID   int          `json:"id"`
-	Body string       `json:"body"`
-	User *EventSender `json:"user"`
+	ID                int              `json:"id"`
+	Body              string           `json:"body"`
+	User              *EventSender     `json:"user"`
+	AuthorAssociation string           `json:"author_association"`
+	Reactions         ReactionsSummary `json:"reactions"`
+}
+
+// ToComment converts the event comment to the API comment type
+func (c *EventComment) ToComment() Comment {
+	comment := Comment{
+		ID:                c.ID,
+		Body:              c.Body,
+		AuthorAssociation: c.AuthorAssociation,
+		Reactions:         c.Reactions,
+	}
+	if c.User != nil {
+		comment.User = User{Login: c.User.Login, Type: c.User.Type}
+	}
+	return comment
 }
@@ -1,7 +1,9 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -20,6 +22,14 @@ type ListOptions struct {
 
 // ListIssues fetches issues from a repository
 func (c *Client) ListIssues(ctx context.Context, org, repo string, opts ListOptions) ([]*models.Issue, error) {
+	issues, _, err := c.ListIssuesPage(ctx, org, repo, opts)
+	return issues, err
+}
+
+// ListIssuesPage fetches one page of issues and reports whether more pages
+// follow. Pull requests are dropped from the page, so a short page does not
+// mean it was the last one.
+func (c *Client) ListIssuesPage(ctx context.Context, org, repo string, opts ListOptions) ([]*models.Issue, bool, error) {
 	if opts.PerPage == 0 {
 		opts.PerPage = 100
 	}
@@ -44,7 +54,7 @@ func (c *Client) ListIssues(ctx context.Context, org, repo string, opts ListOpti
 
 	var apiIssues []Issue
 	if err := c.rest.Get(endpoint, &apiIssues); err != nil {
-		return nil, fmt.Errorf("failed to list issues: %w", err)
+		return nil, false, fmt.Errorf("failed to list issues: %w", err)
 	}
 
 	issues := make([]*models.Issue, 0, len(apiIssues))
@@ -56,7 +66,7 @@ func (c *Client) ListIssues(ctx context.Context, org, repo string, opts ListOpti
 		issues = append(issues, ai.ToModel(org, repo))
 	}
 
-	return issues, nil
+	return issues, len(apiIssues) == opts.PerPage, nil
 }
 
 // GetIssue fetches a single issue
@@ -71,13 +81,34 @@ func (c *Client) GetIssue(ctx context.Context, org, repo string, number int) (*m
 	return ai.ToModel(org, repo), nil
 }
 
+// CreateIssue opens an issue with the given labels and returns it
+func (c *Client) CreateIssue(ctx context.Context, org, repo, title, body string, labels []string) (*Issue, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/issues", org, repo)
+
+	payload := map[string]interface{}{"title": title, "body": body}
+	if len(labels) > 0 {
+		payload["labels"] = labels
+	}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var created Issue
+	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), &created); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+
+	return &created, nil
+}
+
 // ListAllIssues fetches all issues using pagination
 func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state string, batchSize int) ([]*models.Issue, error) {
 	var allIssues []*models.Issue
 	page := 1
 
 	for {
-		issues, err := c.ListIssues(ctx, org, repo, ListOptions{
+		issues, more, err := c.ListIssuesPage(ctx, org, repo, ListOptions{
 			State:   state,
 			PerPage: batchSize,
 			Page:    page,
@@ -86,13 +117,9 @@ func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state stri
 			return nil, err
 		}
 
-		if len(issues) == 0 {
-			break
-		}
-
 		allIssues = append(allIssues, issues...)
 
-		if len(issues) < batchSize {
+		if !more {
 			break
 		}
 		page++
@@ -101,14 +128,10 @@ func (c *Client) ListAllIssues(ctx context.Context, org, repo string, state stri
 	return allIssues, nil
 }
 
-// isPullRequest checks if an issue is actually a pull request.
-// NOTE: The GitHub /issues endpoint includes pull requests, but the go-gh Issue
-// struct does not expose the "pull_request" field from the API response.
-// As a result, this function always returns false and PRs will be indexed
-// alongside issues. This is acceptable for similarity search purposes since
-// PRs often contain relevant context about code changes.
+// isPullRequest checks if an issue is actually a pull request. The /issues
+// endpoints include pull requests and only mark them with a "pull_request" field.
 func (i *Issue) isPullRequest() bool {
-	return false
+	return i.PullRequest != nil
 }
 
 // ListIssuesByLabel fetches issues with a specific label with pagination
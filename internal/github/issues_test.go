@@ -0,0 +1,29 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIssue_IsPullRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want bool
+	}{
+		{name: "issue", json: `{"number": 1, "title": "Crash on save"}`, want: false},
+		{name: "pull request", json: `{"number": 2, "title": "Fix crash", "pull_request": {"url": "https://api.github.com/repos/org/app/pulls/2"}}`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var issue Issue
+			if err := json.Unmarshal([]byte(tt.json), &issue); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := issue.isPullRequest(); got != tt.want {
+				t.Errorf("isPullRequest() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 )
 
 // AddLabels adds labels to an issue
@@ -30,7 +31,7 @@ func (c *Client) AddLabels(ctx context.Context, org, repo string, number int, la
 
 // RemoveLabel removes a label from an issue
 func (c *Client) RemoveLabel(ctx context.Context, org, repo string, number int, label string) error {
-	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", org, repo, number, label)
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", org, repo, number, url.PathEscape(label))
 
 	if err := c.rest.Delete(endpoint, nil); err != nil {
 		return fmt.Errorf("failed to remove label: %w", err)
@@ -39,6 +40,38 @@ func (c *Client) RemoveLabel(ctx context.Context, org, repo string, number int,
 	return nil
 }
 
+// ListRepoLabels fetches every label defined in a repository with pagination
+func (c *Client) ListRepoLabels(ctx context.Context, org, repo string) ([]Label, error) {
+	var allLabels []Label
+	page := 1
+	perPage := 100
+
+	for {
+		endpoint := fmt.Sprintf("repos/%s/%s/labels?per_page=%d&page=%d", org, repo, perPage, page)
+
+		var labels []Label
+		if err := c.rest.Get(endpoint, &labels); err != nil {
+			return nil, fmt.Errorf("failed to list repository labels: %w", err)
+		}
+
+		allLabels = append(allLabels, labels...)
+
+		if len(labels) < perPage {
+			break
+		}
+		page++
+	}
+
+	return allLabels, nil
+}
+
+// Close reasons accepted by CloseIssue
+const (
+	CloseReasonCompleted  = "completed"
+	CloseReasonNotPlanned = "not_planned"
+	CloseReasonDuplicate  = "duplicate"
+)
+
 // CloseIssue closes an issue with an optional reason
 func (c *Client) CloseIssue(ctx context.Context, org, repo string, number int, reason string) error {
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d", org, repo, number)
@@ -60,6 +93,94 @@ func (c *Client) CloseIssue(ctx context.Context, org, repo string, number int, r
 	return nil
 }
 
+// CloseAsDuplicate closes an issue with the duplicate reason and links it to
+// the original through GitHub's native duplicate-of relationship. When the
+// GraphQL link is unavailable it falls back to a REST close with the
+// duplicate reason only.
+func (c *Client) CloseAsDuplicate(ctx context.Context, org, repo string, number int, originalOrg, originalRepo string, originalNumber int) error {
+	err := c.closeAsDuplicateGraphQL(ctx, org, repo, number, originalOrg, originalRepo, originalNumber)
+	if err == nil {
+		return nil
+	}
+
+	fmt.Printf("Warning: failed to link duplicate natively, closing with reason only: %v\n", err)
+	return c.CloseIssue(ctx, org, repo, number, CloseReasonDuplicate)
+}
+
+// closeAsDuplicateGraphQL closes the issue via the closeIssue mutation with duplicateIssueId
+func (c *Client) closeAsDuplicateGraphQL(ctx context.Context, org, repo string, number int, originalOrg, originalRepo string, originalNumber int) error {
+	issueID, err := c.getIssueNodeID(ctx, org, repo, number)
+	if err != nil {
+		return fmt.Errorf("failed to get issue node ID: %w", err)
+	}
+
+	originalID, err := c.getIssueNodeID(ctx, originalOrg, originalRepo, originalNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get original issue node ID: %w", err)
+	}
+
+	query := `
+		mutation CloseAsDuplicate($issueId: ID!, $duplicateIssueId: ID!) {
+			closeIssue(input: {issueId: $issueId, stateReason: DUPLICATE, duplicateIssueId: $duplicateIssueId}) {
+				issue {
+					number
+				}
+			}
+		}
+	`
+
+	variables := map[string]interface{}{
+		"issueId":          issueID,
+		"duplicateIssueId": originalID,
+	}
+
+	var result struct {
+		CloseIssue struct {
+			Issue struct {
+				Number int
+			}
+		}
+	}
+
+	if err := c.graphql.Do(query, variables, &result); err != nil {
+		return fmt.Errorf("failed to close issue as duplicate: %w", err)
+	}
+
+	return nil
+}
+
+// LockIssue locks an issue's conversation
+func (c *Client) LockIssue(ctx context.Context, org, repo string, number int) error {
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/lock", org, repo, number)
+
+	if err := c.rest.Put(endpoint, bytes.NewReader([]byte("{}")), nil); err != nil {
+		return fmt.Errorf("failed to lock issue: %w", err)
+	}
+
+	return nil
+}
+
+// AddAssignees assigns users to an issue
+func (c *Client) AddAssignees(ctx context.Context, org, repo string, number int, assignees []string) error {
+	if len(assignees) == 0 {
+		return nil
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d/assignees", org, repo, number)
+
+	payload := map[string][]string{"assignees": assignees}
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
+		return fmt.Errorf("failed to assign issue: %w", err)
+	}
+
+	return nil
+}
+
 // ReopenIssue reopens a closed issue
 func (c *Client) ReopenIssue(ctx context.Context, org, repo string, number int) error {
 	endpoint := fmt.Sprintf("repos/%s/%s/issues/%d", org, repo, number)
@@ -1,8 +1,12 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 )
 
 // Reaction represents a GitHub reaction
@@ -40,6 +44,22 @@ func (c *Client) ListCommentReactions(ctx context.Context, org, repo string, com
 	return allReactions, nil
 }
 
+// AddCommentReaction reacts to a comment, e.g. to acknowledge a command
+func (c *Client) AddCommentReaction(ctx context.Context, org, repo string, commentID int, content string) error {
+	endpoint := fmt.Sprintf("repos/%s/%s/issues/comments/%d/reactions", org, repo, commentID)
+
+	jsonBody, err := json.Marshal(map[string]string{"content": content})
+	if err != nil {
+		return err
+	}
+
+	if err := c.rest.Post(endpoint, bytes.NewReader(jsonBody), nil); err != nil {
+		return fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	return nil
+}
+
 // HasReaction checks if a comment has a specific reaction type from any user
 func (c *Client) HasReaction(ctx context.Context, org, repo string, commentID int, reactionType string) (bool, error) {
 	reactions, err := c.ListCommentReactions(ctx, org, repo, commentID)
@@ -73,34 +93,43 @@ func (c *Client) GetReactionUsers(ctx context.Context, org, repo string, comment
 	return users, nil
 }
 
-// CheckReactionDecision checks reactions and returns decision: "approve", "cancel", or "none"
-// approveReaction is typically "+1" (thumbs up)
-// cancelReaction is typically "-1" (thumbs down)
-func (c *Client) CheckReactionDecision(ctx context.Context, org, repo string, commentID int, approveReaction, cancelReaction string) (string, error) {
-	reactions, err := c.ListCommentReactions(ctx, org, repo, commentID)
-	if err != nil {
-		return "", err
-	}
-
-	hasApprove := false
-	hasCancel := false
+// CollaboratorPermission returns a user's role in a repository: "admin",
+// "maintain", "write", "triage", "read" or "none". Custom roles are reported
+// by the base permission they extend.
+func (c *Client) CollaboratorPermission(ctx context.Context, org, repo, user string) (string, error) {
+	endpoint := fmt.Sprintf("repos/%s/%s/collaborators/%s/permission", org, repo, url.PathEscape(user))
 
-	for _, r := range reactions {
-		if r.Content == approveReaction {
-			hasApprove = true
-		}
-		if r.Content == cancelReaction {
-			hasCancel = true
+	var result struct {
+		Permission string `json:"permission"`
+		RoleName   string `json:"role_name"`
+	}
+	if err := c.rest.Get(endpoint, &result); err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return "none", nil
 		}
+		return "", fmt.Errorf("failed to get collaborator permission: %w", err)
 	}
 
-	// Cancel takes precedence
-	if hasCancel {
-		return "cancel", nil
+	switch result.RoleName {
+	case "admin", "maintain", "write", "triage", "read":
+		return result.RoleName, nil
 	}
-	if hasApprove {
-		return "approve", nil
+	return result.Permission, nil
+}
+
+// IsTeamMember checks if a user is an active member of an organization team
+func (c *Client) IsTeamMember(ctx context.Context, org, team, user string) (bool, error) {
+	endpoint := fmt.Sprintf("orgs/%s/teams/%s/memberships/%s", org, url.PathEscape(team), url.PathEscape(user))
+
+	var membership struct {
+		State string `json:"state"`
+	}
+	if err := c.rest.Get(endpoint, &membership); err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get team membership: %w", err)
 	}
 
-	return "none", nil
+	return membership.State == "active", nil
 }
@@ -5,39 +5,47 @@ import (
 	"fmt"
 )
 
-// TransferIssue transfers an issue to another repository
-func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int, targetRepo string) error {
+// TransferredIssue is an issue at its new location after a transfer
+type TransferredIssue struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	NodeID string `json:"id"`
+}
+
+// TransferIssue transfers an issue to another repository and returns it at
+// its new location
+func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int, targetRepo string) (*TransferredIssue, error) {
 	targetOrg, targetRepoName, err := ParseRepo(targetRepo)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Use GraphQL mutation for issue transfer
 	var mutation struct {
 		TransferIssue struct {
-			Issue struct {
-				Number int
-			}
+			Issue TransferredIssue
 		} `graphql:"transferIssue(input: $input)"`
 	}
 
 	// First, get the issue node ID
 	nodeID, err := c.getIssueNodeID(ctx, org, repo, number)
 	if err != nil {
-		return fmt.Errorf("failed to get issue node ID: %w", err)
+		return nil, fmt.Errorf("failed to get issue node ID: %w", err)
 	}
 
 	// Get target repo node ID
 	targetRepoID, err := c.getRepoNodeID(ctx, targetOrg, targetRepoName)
 	if err != nil {
-		return fmt.Errorf("failed to get target repo node ID: %w", err)
+		return nil, fmt.Errorf("failed to get target repo node ID: %w", err)
 	}
 
 	query := `
 		mutation TransferIssue($issueId: ID!, $repositoryId: ID!) {
 			transferIssue(input: {issueId: $issueId, repositoryId: $repositoryId}) {
 				issue {
+					id
 					number
+					url
 				}
 			}
 		}
@@ -49,10 +57,10 @@ func (c *Client) TransferIssue(ctx context.Context, org, repo string, number int
 	}
 
 	if err := c.graphql.Do(query, variables, &mutation); err != nil {
-		return fmt.Errorf("failed to transfer issue: %w", err)
+		return nil, fmt.Errorf("failed to transfer issue: %w", err)
 	}
 
-	return nil
+	return &mutation.TransferIssue.Issue, nil
 }
 
 // getIssueNodeID fetches the GraphQL node ID for an issue
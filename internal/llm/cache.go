@@ -0,0 +1,115 @@
+package llm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// CachedProvider wraps a Provider and stores responses in a JSON file keyed
+// by prompt, so repeated evaluation runs do not call the LLM again
+type CachedProvider struct {
+	provider Provider
+	path     string
+	mu       sync.Mutex
+	entries  map[string]string
+	dirty    bool
+}
+
+// NewCachedProvider creates a caching provider backed by the file at path.
+// A missing file starts an empty cache.
+func NewCachedProvider(provider Provider, path string) (*CachedProvider, error) {
+	c := &CachedProvider{
+		provider: provider,
+		path:     path,
+		entries:  make(map[string]string),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("failed to read LLM cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM cache: %w", err)
+	}
+
+	return c, nil
+}
+
+// Complete generates a completion for the given prompt
+func (c *CachedProvider) Complete(ctx context.Context, prompt string) (string, error) {
+	return c.CompleteWithSystem(ctx, "", prompt)
+}
+
+// CompleteWithSystem returns the cached response or calls the wrapped provider
+func (c *CachedProvider) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	key := cacheKey(system, prompt)
+
+	c.mu.Lock()
+	response, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return response, nil
+	}
+
+	response, err := c.provider.CompleteWithSystem(ctx, system, prompt)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.entries[key] = response
+	c.dirty = true
+	c.mu.Unlock()
+
+	return response, nil
+}
+
+// Close saves the cache and closes the wrapped provider
+func (c *CachedProvider) Close() error {
+	saveErr := c.save()
+	if err := c.provider.Close(); err != nil {
+		return err
+	}
+	return saveErr
+}
+
+// save writes the cache file if new responses were added
+func (c *CachedProvider) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal LLM cache: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("failed to create LLM cache directory: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write LLM cache: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// cacheKey hashes a system and user prompt pair
+func cacheKey(system, prompt string) string {
+	h := sha256.Sum256([]byte(system + "\x00" + prompt))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,169 @@
+// Package match scores issues against weighted keyword rules. It is shared
+// by the label classifier and transfer rules.
+package match
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Fields a rule can be scoped to
+const (
+	FieldAny   = ""
+	FieldTitle = "title"
+	FieldBody  = "body"
+	FieldCode  = "code"
+)
+
+// DefaultWeight is used for rules without a weight
+const DefaultWeight = 0.5
+
+// KeywordWeight is the weight of plain keywords. A single match is enough,
+// as it was before keywords had weights.
+const KeywordWeight = 1.0
+
+// codePattern matches fenced code blocks and inline code spans
+var codePattern = regexp.MustCompile("(?s)```.*?```|`[^`\n]+`")
+
+// Set is a compiled list of keyword rules
+type Set struct {
+	rules []rule
+}
+
+// rule is a compiled keyword rule
+type rule struct {
+	pattern string
+	re      *regexp.Regexp
+	field   string
+	weight  float64
+}
+
+// FromKeywords converts plain keywords to substring rules with KeywordWeight
+func FromKeywords(keywords []string) []config.KeywordRule {
+	rules := make([]config.KeywordRule, 0, len(keywords))
+	for _, kw := range keywords {
+		if kw == "" {
+			continue
+		}
+		rules = append(rules, config.KeywordRule{Pattern: kw, Weight: KeywordWeight})
+	}
+	return rules
+}
+
+// Compile compiles keyword rules. Patterns are case-insensitive.
+func Compile(rules []config.KeywordRule) (*Set, error) {
+	set := &Set{rules: make([]rule, 0, len(rules))}
+	for _, r := range rules {
+		re, err := CompileRule(r)
+		if err != nil {
+			return nil, err
+		}
+
+		weight := r.Weight
+		if weight == 0 {
+			weight = DefaultWeight
+		}
+
+		set.rules = append(set.rules, rule{
+			pattern: r.Pattern,
+			re:      re,
+			field:   r.Field,
+			weight:  weight,
+		})
+	}
+	return set, nil
+}
+
+// CompileRule compiles the pattern of a single rule
+func CompileRule(r config.KeywordRule) (*regexp.Regexp, error) {
+	expr := r.Pattern
+	if !r.Regex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if r.WholeWord {
+		expr = `\b(?:` + expr + `)\b`
+	}
+
+	re, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", r.Pattern, err)
+	}
+	return re, nil
+}
+
+// Len returns the number of rules in the set
+func (s *Set) Len() int {
+	return len(s.rules)
+}
+
+// Score returns a confidence between 0 and 1 and the patterns that matched.
+// Positive matches combine as independent evidence, 1 - Π(1 - w), so every
+// additional match raises confidence regardless of how many rules exist.
+// Negative matches then scale the result by (1 + w).
+func (s *Set) Score(issue *models.Issue) (float64, []string) {
+	fields := splitFields(issue)
+
+	miss := 1.0
+	penalty := 1.0
+	positive := false
+	var matched []string
+
+	for _, r := range s.rules {
+		if !r.re.MatchString(fields[r.field]) {
+			continue
+		}
+		matched = append(matched, r.pattern)
+		if r.weight > 0 {
+			positive = true
+			miss *= 1 - min(r.weight, 1)
+		} else {
+			penalty *= 1 + max(r.weight, -1)
+		}
+	}
+
+	if !positive {
+		return 0, matched
+	}
+	return (1 - miss) * penalty, matched
+}
+
+// splitFields extracts the text each rule field is matched against
+func splitFields(issue *models.Issue) map[string]string {
+	code := strings.Join(codePattern.FindAllString(issue.Body, -1), "\n")
+	return map[string]string{
+		FieldAny:   issue.Title + "\n" + issue.Body,
+		FieldTitle: issue.Title,
+		FieldBody:  issue.Body,
+		FieldCode:  code,
+	}
+}
+
+// formHeading matches the "### Label" headings GitHub renders for issue form fields
+var formHeading = regexp.MustCompile(`(?m)^###[ \t]+(.+?)[ \t]*$`)
+
+// FormFields parses the fields of an issue created from an issue form. Keys
+// are the lowercased field labels; unanswered fields ("_No response_") are empty.
+func FormFields(body string) map[string]string {
+	fields := make(map[string]string)
+
+	headings := formHeading.FindAllStringSubmatchIndex(body, -1)
+	for i, h := range headings {
+		end := len(body)
+		if i+1 < len(headings) {
+			end = headings[i+1][0]
+		}
+
+		label := strings.ToLower(strings.TrimSpace(body[h[2]:h[3]]))
+		value := strings.TrimSpace(body[h[1]:end])
+		if value == "_No response_" {
+			value = ""
+		}
+		fields[label] = value
+	}
+
+	return fields
+}
@@ -0,0 +1,64 @@
+package match
+
+import (
+	"math"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestSet_Score(t *testing.T) {
+	issue := &models.Issue{
+		Title: "Crash when saving",
+		Body:  "The app errors out.\n\n```\npanic: nil map\n```",
+	}
+
+	tests := []struct {
+		name  string
+		rules []config.KeywordRule
+		want  float64
+	}{
+		{"no match", []config.KeywordRule{{Pattern: "feature"}}, 0},
+		{"single default weight", []config.KeywordRule{{Pattern: "crash"}}, 0.5},
+		{"matches combine", []config.KeywordRule{{Pattern: "crash"}, {Pattern: "error"}}, 0.75},
+		{"unmatched rules do not dilute", []config.KeywordRule{{Pattern: "crash"}, {Pattern: "qqq"}, {Pattern: "zzz"}, {Pattern: "yyy"}}, 0.5},
+		{"whole word", []config.KeywordRule{{Pattern: "error", WholeWord: true}}, 0},
+		{"regex", []config.KeywordRule{{Pattern: `err(or)?s?\b`, Regex: true, Weight: 0.8}}, 0.8},
+		{"title scope", []config.KeywordRule{{Pattern: "panic", Field: FieldTitle}}, 0},
+		{"code scope", []config.KeywordRule{{Pattern: "panic", Field: FieldCode, Weight: 0.9}}, 0.9},
+		{"negative keyword", []config.KeywordRule{{Pattern: "crash", Weight: 0.8}, {Pattern: "saving", Weight: -0.5}}, 0.4},
+		{"veto", []config.KeywordRule{{Pattern: "crash"}, {Pattern: "saving", Weight: -1}}, 0},
+		{"only negative", []config.KeywordRule{{Pattern: "crash", Weight: -0.5}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set, err := Compile(tt.rules)
+			if err != nil {
+				t.Fatalf("Compile() error = %v", err)
+			}
+			if got, _ := set.Score(issue); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_InvalidRegex(t *testing.T) {
+	if _, err := Compile([]config.KeywordRule{{Pattern: "(", Regex: true}}); err == nil {
+		t.Error("Compile() expected error for invalid regex")
+	}
+}
+
+func TestFormFields(t *testing.T) {
+	body := "### Version\n\nv1.2.3\n\n### Operating system\n\nLinux\n\n### Logs\n\n_No response_\n"
+
+	fields := FormFields(body)
+	if fields["version"] != "v1.2.3" || fields["operating system"] != "Linux" {
+		t.Errorf("FormFields() = %v", fields)
+	}
+	if v, ok := fields["logs"]; !ok || v != "" {
+		t.Errorf("FormFields()[logs] = %q, %v, want empty answer", v, ok)
+	}
+}
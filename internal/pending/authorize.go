@@ -0,0 +1,234 @@
+package pending
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Decision outcomes
+const (
+	DecisionApprove = "approve"
+	DecisionCancel  = "cancel"
+	DecisionNone    = "none"
+)
+
+// Decision is the outcome of the reactions on a pending action's comment
+type Decision struct {
+	Outcome   string
+	DecidedBy []string // logins of the authorized users who decided
+}
+
+// ReactionClient is the GitHub access needed to authorize reactions
+type ReactionClient interface {
+	GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error)
+	ListCommentReactions(ctx context.Context, org, repo string, commentID int) ([]github.Reaction, error)
+	CollaboratorPermission(ctx context.Context, org, repo, user string) (string, error)
+	IsTeamMember(ctx context.Context, org, team, user string) (bool, error)
+}
+
+// Authorizer decides pending actions from the reactions of authorized users
+type Authorizer struct {
+	gh      ReactionClient
+	cfg     *config.DelayedActionsConfig
+	allowed map[string]bool // "org/repo/login" -> collaborator or team check result
+}
+
+// NewAuthorizer creates a new reaction authorizer
+func NewAuthorizer(gh ReactionClient, cfg *config.DelayedActionsConfig) *Authorizer {
+	return &Authorizer{
+		gh:      gh,
+		cfg:     cfg,
+		allowed: make(map[string]bool),
+	}
+}
+
+// Decide counts the approve and cancel reactions of authorized users on a
+// comment. Cancel wins when both reach the quorum.
+func (a *Authorizer) Decide(ctx context.Context, org, repo string, issueNumber, commentID int) (*Decision, error) {
+	reactions, err := a.gh.ListCommentReactions(ctx, org, repo, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var approvers, cancellers []string
+	var author string
+	authorFetched := false
+
+	for _, r := range reactions {
+		if r.Content != a.cfg.ApproveReaction && r.Content != a.cfg.CancelReaction {
+			continue
+		}
+
+		if a.hasRole("author") && !authorFetched {
+			issue, err := a.gh.GetIssue(ctx, org, repo, issueNumber)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get issue author: %w", err)
+			}
+			author = issue.Author
+			authorFetched = true
+		}
+
+		ok, err := a.Authorized(ctx, org, repo, author, r.User.Login)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		if r.Content == a.cfg.CancelReaction {
+			cancellers = appendUnique(cancellers, r.User.Login)
+		} else {
+			approvers = appendUnique(approvers, r.User.Login)
+		}
+	}
+
+	return decide(approvers, cancellers, a.cfg.Authorization.Quorum), nil
+}
+
+// Authorized reports whether a user may approve or cancel actions on an
+// issue opened by author
+func (a *Authorizer) Authorized(ctx context.Context, org, repo, author, user string) (bool, error) {
+	if user == "" {
+		return false, nil
+	}
+	if a.hasRole("anyone") {
+		return true, nil
+	}
+	if a.hasRole("author") && strings.EqualFold(user, author) {
+		return true, nil
+	}
+
+	key := strings.ToLower(org + "/" + repo + "/" + user)
+	if ok, cached := a.allowed[key]; cached {
+		return ok, nil
+	}
+
+	ok, err := a.isMaintainer(ctx, org, repo, user)
+	if err != nil {
+		return false, err
+	}
+	a.allowed[key] = ok
+	return ok, nil
+}
+
+// isMaintainer checks the collaborator permission and team memberships of a user
+func (a *Authorizer) isMaintainer(ctx context.Context, org, repo, user string) (bool, error) {
+	auth := a.cfg.Authorization
+
+	if a.hasRole("collaborator") {
+		permission, err := a.gh.CollaboratorPermission(ctx, org, repo, user)
+		if err != nil {
+			return false, err
+		}
+		if rank := config.PermissionRank(permission); rank >= 0 && rank >= config.PermissionRank(auth.MinPermission) {
+			return true, nil
+		}
+	}
+
+	for _, team := range auth.Teams {
+		teamOrg, slug, ok := strings.Cut(team, "/")
+		if !ok {
+			continue
+		}
+		member, err := a.gh.IsTeamMember(ctx, teamOrg, slug, user)
+		if err != nil {
+			return false, err
+		}
+		if member {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// hasRole reports whether a role is allowed to decide
+func (a *Authorizer) hasRole(role string) bool {
+	for _, r := range a.cfg.Authorization.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// decide applies the quorum to the authorized approvers and cancellers
+func decide(approvers, cancellers []string, quorum int) *Decision {
+	quorum = max(quorum, 1)
+
+	if len(cancellers) >= quorum {
+		return &Decision{Outcome: DecisionCancel, DecidedBy: cancellers}
+	}
+	if len(approvers) >= quorum {
+		return &Decision{Outcome: DecisionApprove, DecidedBy: approvers}
+	}
+	return &Decision{Outcome: DecisionNone}
+}
+
+// appendUnique appends a login unless it is already present
+func appendUnique(logins []string, login string) []string {
+	for _, l := range logins {
+		if strings.EqualFold(l, login) {
+			return logins
+		}
+	}
+	return append(logins, login)
+}
+
+// FormatDecidedBy renders the users who decided as "@a, @b"
+func FormatDecidedBy(logins []string) string {
+	mentions := make([]string, len(logins))
+	for i, l := range logins {
+		mentions[i] = "@" + l
+	}
+	return strings.Join(mentions, ", ")
+}
+
+// FormatAuthorization describes whose reactions count, for the comments that
+// ask for them. It is empty when anyone's single reaction decides.
+func FormatAuthorization(auth config.ReactionAuthConfig) string {
+	var who []string
+	anyone := false
+	for _, role := range auth.Roles {
+		switch role {
+		case "anyone":
+			anyone = true
+		case "author":
+			who = append(who, "the issue author")
+		case "collaborator":
+			who = append(who, fmt.Sprintf("collaborators with %s access or higher", auth.MinPermission))
+		}
+	}
+	for _, team := range auth.Teams {
+		who = append(who, "members of "+team)
+	}
+
+	var sb strings.Builder
+	if !anyone && len(who) > 0 {
+		sb.WriteString("Only reactions from " + joinList(who) + " count.")
+	}
+	if auth.Quorum > 1 {
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(fmt.Sprintf("%d reactions are needed to decide.", auth.Quorum))
+	}
+	if sb.Len() == 0 {
+		return ""
+	}
+	return "\n_" + sb.String() + "_\n"
+}
+
+// joinList joins items as "a", "a or b" or "a, b or c"
+func joinList(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
@@ -0,0 +1,133 @@
+package pending
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// fakeReactions serves reactions, permissions and team memberships from maps
+type fakeReactions struct {
+	reactions   []github.Reaction
+	author      string
+	permissions map[string]string
+	teams       map[string][]string // "org/slug" -> members
+}
+
+func (f *fakeReactions) GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error) {
+	return &models.Issue{Org: org, Repo: repo, Number: number, Author: f.author}, nil
+}
+
+func (f *fakeReactions) ListCommentReactions(ctx context.Context, org, repo string, commentID int) ([]github.Reaction, error) {
+	return f.reactions, nil
+}
+
+func (f *fakeReactions) CollaboratorPermission(ctx context.Context, org, repo, user string) (string, error) {
+	if p, ok := f.permissions[user]; ok {
+		return p, nil
+	}
+	return "none", nil
+}
+
+func (f *fakeReactions) IsTeamMember(ctx context.Context, org, team, user string) (bool, error) {
+	for _, m := range f.teams[org+"/"+team] {
+		if m == user {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func reaction(content, login string) github.Reaction {
+	return github.Reaction{Content: content, User: github.User{Login: login}}
+}
+
+func TestAuthorizerDecide(t *testing.T) {
+	gh := &fakeReactions{
+		author:      "reporter",
+		permissions: map[string]string{"maint": "write", "reader": "read", "triager": "triage"},
+		teams:       map[string][]string{"org/core": {"teammate"}},
+	}
+
+	tests := []struct {
+		name      string
+		auth      config.ReactionAuthConfig
+		reactions []github.Reaction
+		want      string
+		wantBy    []string
+	}{
+		{
+			name:      "drive-by cancel is ignored",
+			auth:      config.ReactionAuthConfig{Roles: []string{"author", "collaborator"}, MinPermission: "triage", Quorum: 1},
+			reactions: []github.Reaction{reaction("-1", "stranger"), reaction("+1", "maint")},
+			want:      DecisionApprove,
+			wantBy:    []string{"maint"},
+		},
+		{
+			name:      "author can cancel",
+			auth:      config.ReactionAuthConfig{Roles: []string{"author", "collaborator"}, MinPermission: "triage", Quorum: 1},
+			reactions: []github.Reaction{reaction("+1", "maint"), reaction("-1", "reporter")},
+			want:      DecisionCancel,
+			wantBy:    []string{"reporter"},
+		},
+		{
+			name:      "read permission is below triage",
+			auth:      config.ReactionAuthConfig{Roles: []string{"collaborator"}, MinPermission: "triage", Quorum: 1},
+			reactions: []github.Reaction{reaction("+1", "reader"), reaction("+1", "reporter")},
+			want:      DecisionNone,
+		},
+		{
+			name:      "team members are allowed",
+			auth:      config.ReactionAuthConfig{Roles: []string{"collaborator"}, MinPermission: "admin", Teams: []string{"org/core"}, Quorum: 1},
+			reactions: []github.Reaction{reaction("-1", "maint"), reaction("-1", "teammate")},
+			want:      DecisionCancel,
+			wantBy:    []string{"teammate"},
+		},
+		{
+			name:      "quorum counts distinct users",
+			auth:      config.ReactionAuthConfig{Roles: []string{"collaborator"}, MinPermission: "triage", Quorum: 2},
+			reactions: []github.Reaction{reaction("+1", "maint"), reaction("+1", "maint"), reaction("-1", "triager")},
+			want:      DecisionNone,
+		},
+		{
+			name:      "quorum reached",
+			auth:      config.ReactionAuthConfig{Roles: []string{"collaborator"}, MinPermission: "triage", Quorum: 2},
+			reactions: []github.Reaction{reaction("+1", "maint"), reaction("+1", "triager")},
+			want:      DecisionApprove,
+			wantBy:    []string{"maint", "triager"},
+		},
+		{
+			name:      "anyone restores the old behavior",
+			auth:      config.ReactionAuthConfig{Roles: []string{"anyone"}, Quorum: 1},
+			reactions: []github.Reaction{reaction("+1", "maint"), reaction("-1", "stranger")},
+			want:      DecisionCancel,
+			wantBy:    []string{"stranger"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh.reactions = tt.reactions
+			cfg := &config.DelayedActionsConfig{ApproveReaction: "+1", CancelReaction: "-1", Authorization: tt.auth}
+
+			got, err := NewAuthorizer(gh, cfg).Decide(context.Background(), "org", "repo", 1, 10)
+			if err != nil {
+				t.Fatalf("Decide() error = %v", err)
+			}
+			if got.Outcome != tt.want {
+				t.Errorf("Outcome = %s, want %s", got.Outcome, tt.want)
+			}
+			if len(got.DecidedBy) != len(tt.wantBy) {
+				t.Fatalf("DecidedBy = %v, want %v", got.DecidedBy, tt.wantBy)
+			}
+			for i := range tt.wantBy {
+				if got.DecidedBy[i] != tt.wantBy[i] {
+					t.Errorf("DecidedBy = %v, want %v", got.DecidedBy, tt.wantBy)
+				}
+			}
+		})
+	}
+}
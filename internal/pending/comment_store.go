@@ -0,0 +1,164 @@
+package pending
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// CommentStore keeps pending actions on GitHub itself: a label marks the
+// issue and the action is read back from the metadata in the bot's comment.
+// It needs no local state but keeps no lifecycle history.
+type CommentStore struct {
+	gh  *github.Client
+	cfg *config.DelayedActionsConfig
+
+	self        string // login of the authenticated identity
+	selfChecked bool
+}
+
+// NewCommentStore creates a store backed by issue labels and comments. cfg
+// supplies the signing secret and bot logins; it may be nil.
+func NewCommentStore(gh *github.Client, cfg *config.DelayedActionsConfig) *CommentStore {
+	if cfg == nil {
+		cfg = &config.DelayedActionsConfig{}
+	}
+	return &CommentStore{gh: gh, cfg: cfg}
+}
+
+// Save is a no-op; the label and comment already describe the action
+func (s *CommentStore) Save(ctx context.Context, action *PendingAction) error {
+	return nil
+}
+
+// Transition is a no-op; removing the label ends the action
+func (s *CommentStore) Transition(ctx context.Context, action *PendingAction, state State, by []string) error {
+	return nil
+}
+
+// actionTypes lists every action type in the order Find checks their labels
+var actionTypes = []ActionType{ActionTypeTransfer, ActionTypeClose, ActionTypeTriage, ActionTypeNeedsInfo}
+
+// Pending finds all pending actions for issues with pending labels
+func (s *CommentStore) Pending(ctx context.Context, org, repo string) ([]*PendingAction, error) {
+	var actions []*PendingAction
+
+	for _, actionType := range actionTypes {
+		label, _ := labelFor(actionType)
+		issues, err := s.gh.ListIssuesByLabel(ctx, org, repo, label)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s issues: %w", label, err)
+		}
+
+		for _, issue := range issues {
+			action, err := s.extractPendingAction(ctx, issue, actionType)
+			if err == nil && action != nil {
+				actions = append(actions, action)
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// extractPendingAction extracts the newest pending action of a type from
+// issue comments
+func (s *CommentStore) extractPendingAction(ctx context.Context, issue *models.Issue, actionType ActionType) (*PendingAction, error) {
+	comments, err := s.gh.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first, so a rescheduled action wins over the one it replaced
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		if !metadataRegex.MatchString(comment.Body) || !s.Trusted(ctx, comment.User) {
+			continue
+		}
+
+		action, err := ParsePendingActionMetadata(comment.Body, s.cfg.SigningSecret)
+		if errors.Is(err, ErrInvalidSignature) {
+			log.Printf("Warning: ignoring pending action with invalid signature on %s/%s#%d", issue.Org, issue.Repo, issue.Number)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		// The signed payload must describe this issue
+		if action.Type == actionType && action.IssueNumber == issue.Number &&
+			(action.Org == "" || strings.EqualFold(action.Org, issue.Org)) &&
+			(action.Repo == "" || strings.EqualFold(action.Repo, issue.Repo)) {
+			action.Org = issue.Org
+			action.Repo = issue.Repo
+			action.CommentID = comment.ID
+			return action, nil
+		}
+	}
+
+	return nil, fmt.Errorf("pending action not found")
+}
+
+// Find gets the pending action for a specific issue from its labels and
+// comments. Transfers take precedence over closes, and closes over triage
+// actions.
+func (s *CommentStore) Find(ctx context.Context, issue *models.Issue) (*PendingAction, error) {
+	for _, actionType := range actionTypes {
+		label, _ := labelFor(actionType)
+		if !hasLabel(issue, label) {
+			continue
+		}
+		action, err := s.extractPendingAction(ctx, issue, actionType)
+		if err == nil && action != nil {
+			return action, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// hasLabel reports whether an issue carries a label
+func hasLabel(issue *models.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultBotLogin is the identity of the Actions GITHUB_TOKEN, trusted when
+// no bot logins are configured and the token cannot identify itself
+const DefaultBotLogin = "github-actions[bot]"
+
+// Trusted reports whether a comment was written by simili's own identity:
+// a configured bot login, or else the authenticated user. Tokens that cannot
+// identify themselves (Actions and GitHub App tokens) only trust
+// DefaultBotLogin; other apps' bots are never trusted.
+func (s *CommentStore) Trusted(ctx context.Context, user github.User) bool {
+	if len(s.cfg.BotLogins) > 0 {
+		for _, login := range s.cfg.BotLogins {
+			if strings.EqualFold(login, user.Login) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !s.selfChecked && s.gh != nil {
+		s.selfChecked = true
+		if login, err := s.gh.AuthenticatedLogin(ctx); err == nil {
+			s.self = login
+		}
+	}
+	if s.self != "" {
+		return strings.EqualFold(s.self, user.Login)
+	}
+	return strings.EqualFold(user.Login, DefaultBotLogin)
+}
@@ -0,0 +1,34 @@
+package pending
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+)
+
+func TestCommentStoreTrusted(t *testing.T) {
+	tests := []struct {
+		name      string
+		botLogins []string
+		user      github.User
+		want      bool
+	}{
+		{"actions bot by default", nil, github.User{Login: "github-actions[bot]", Type: "Bot"}, true},
+		{"other app bot", nil, github.User{Login: "evil-app[bot]", Type: "Bot"}, false},
+		{"user", nil, github.User{Login: "reporter", Type: "User"}, false},
+		{"configured login", []string{"simili-bot"}, github.User{Login: "Simili-Bot", Type: "User"}, true},
+		{"actions bot not configured", []string{"simili-bot"}, github.User{Login: "github-actions[bot]", Type: "Bot"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No client: the token cannot identify itself
+			store := NewCommentStore(nil, &config.DelayedActionsConfig{BotLogins: tt.botLogins})
+			if got := store.Trusted(context.Background(), tt.user); got != tt.want {
+				t.Errorf("Trusted(%s) = %v, want %v", tt.user.Login, got, tt.want)
+			}
+		})
+	}
+}
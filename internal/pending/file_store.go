@@ -0,0 +1,254 @@
+package pending
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultStorePath is where the file store keeps its database
+const DefaultStorePath = ".simili/pending.db"
+
+// lockTimeout bounds how long an operation waits for another process
+// holding the database
+const lockTimeout = 30 * time.Second
+
+// recordsBucket holds the records, keyed by issue and then sequence
+var recordsBucket = []byte("records")
+
+// FileStore keeps pending actions and their lifecycle history in a local
+// BoltDB database, so finding due actions does not need any GitHub API calls.
+// The database is opened for each operation and its file lock serializes
+// processes, so an event job and a scheduled run on the same host do not lose
+// each other's updates. The file must live on a disk that outlives the runs.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a store backed by the database at path
+func NewFileStore(path string) *FileStore {
+	if path == "" {
+		path = DefaultStorePath
+	}
+	return &FileStore{path: path}
+}
+
+// Save records a newly scheduled action. Rescheduling an issue's open action
+// of the same type replaces it and continues its history.
+func (s *FileStore) Save(ctx context.Context, action *PendingAction) error {
+	return s.update(func(b *bolt.Bucket) error {
+		key, rec, err := openRecord(b, action.Org, action.Repo, action.IssueNumber, action.Type)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			if key, err = newKey(b, action); err != nil {
+				return err
+			}
+			rec = &Record{}
+		}
+		rec.Action = *action
+		rec.transition(StateScheduled, action.ScheduledAt, nil)
+		return putRecord(b, key, rec)
+	})
+}
+
+// Transition moves an action to a new state
+func (s *FileStore) Transition(ctx context.Context, action *PendingAction, state State, by []string) error {
+	return s.update(func(b *bolt.Bucket) error {
+		key, rec, err := openRecord(b, action.Org, action.Repo, action.IssueNumber, action.Type)
+		if err != nil {
+			return err
+		}
+		if rec == nil {
+			// Scheduled before the file store was enabled
+			if key, err = newKey(b, action); err != nil {
+				return err
+			}
+			rec = &Record{Action: *action}
+			rec.transition(StateScheduled, action.ScheduledAt, nil)
+		}
+		if rec.State == state {
+			return nil
+		}
+		rec.transition(state, time.Now(), by)
+		return putRecord(b, key, rec)
+	})
+}
+
+// Pending lists the open actions of a repository, soonest to expire first
+func (s *FileStore) Pending(ctx context.Context, org, repo string) ([]*PendingAction, error) {
+	var actions []*PendingAction
+	err := s.view(func(b *bolt.Bucket) error {
+		return scan(b, repoPrefix(org, repo), func(_ []byte, rec *Record) error {
+			if !rec.State.IsTerminal() {
+				action := rec.Action
+				actions = append(actions, &action)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].ExpiresAt.Before(actions[j].ExpiresAt)
+	})
+	return actions, nil
+}
+
+// Find returns the open action of an issue, or nil
+func (s *FileStore) Find(ctx context.Context, issue *models.Issue) (*PendingAction, error) {
+	var found *PendingAction
+	err := s.view(func(b *bolt.Bucket) error {
+		return scan(b, issuePrefix(issue.Org, issue.Repo, issue.Number), func(_ []byte, rec *Record) error {
+			if found == nil && !rec.State.IsTerminal() {
+				action := rec.Action
+				found = &action
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// History returns every record of an issue, oldest first
+func (s *FileStore) History(ctx context.Context, org, repo string, number int) ([]*Record, error) {
+	var records []*Record
+	err := s.view(func(b *bolt.Bucket) error {
+		return scan(b, issuePrefix(org, repo, number), func(_ []byte, rec *Record) error {
+			records = append(records, rec)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// update runs fn in a write transaction, creating the database if needed
+func (s *FileStore) update(fn func(b *bolt.Bucket) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create pending store directory: %w", err)
+	}
+
+	db, err := bolt.Open(s.path, 0o644, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open pending store: %w", err)
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(recordsBucket)
+		if err != nil {
+			return fmt.Errorf("failed to create pending store bucket: %w", err)
+		}
+		return fn(b)
+	})
+}
+
+// view runs fn in a read transaction; a missing database is an empty store
+func (s *FileStore) view(fn func(b *bolt.Bucket) error) error {
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	db, err := bolt.Open(s.path, 0o644, &bolt.Options{Timeout: lockTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open pending store: %w", err)
+	}
+	defer db.Close()
+
+	return db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(recordsBucket)
+		if b == nil {
+			return nil
+		}
+		return fn(b)
+	})
+}
+
+// scan calls fn for every record whose key starts with prefix, in key order
+func scan(b *bolt.Bucket, prefix []byte, fn func(key []byte, rec *Record) error) error {
+	c := b.Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		var rec Record
+		if err := json.Unmarshal(v, &rec); err != nil {
+			return fmt.Errorf("failed to parse pending record %s: %w", k, err)
+		}
+		if err := fn(k, &rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openRecord returns the open record of an issue's action type and its key,
+// or nil
+func openRecord(b *bolt.Bucket, org, repo string, number int, actionType ActionType) ([]byte, *Record, error) {
+	var key []byte
+	var open *Record
+	err := scan(b, issuePrefix(org, repo, number), func(k []byte, rec *Record) error {
+		if open == nil && !rec.State.IsTerminal() && rec.Action.Type == actionType {
+			key, open = append([]byte(nil), k...), rec
+		}
+		return nil
+	})
+	return key, open, err
+}
+
+// putRecord writes a record under key
+func putRecord(b *bolt.Bucket, key []byte, rec *Record) error {
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending record: %w", err)
+	}
+	if err := b.Put(key, raw); err != nil {
+		return fmt.Errorf("failed to write pending record: %w", err)
+	}
+	return nil
+}
+
+// newKey returns the key of a new record of the action's issue. The
+// zero-padded sequence keeps an issue's records in creation order.
+func newKey(b *bolt.Bucket, action *PendingAction) ([]byte, error) {
+	seq, err := b.NextSequence()
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate pending record: %w", err)
+	}
+	return append(issuePrefix(action.Org, action.Repo, action.IssueNumber), fmt.Sprintf("%020d", seq)...), nil
+}
+
+// repoPrefix is the key prefix of a repository's records
+func repoPrefix(org, repo string) []byte {
+	return []byte(strings.ToLower(org) + "/" + strings.ToLower(repo) + "#")
+}
+
+// issuePrefix is the key prefix of an issue's records
+func issuePrefix(org, repo string, number int) []byte {
+	return append(repoPrefix(org, repo), fmt.Sprintf("%d/", number)...)
+}
+
+// transition appends a state change to the record's history
+func (r *Record) transition(state State, at time.Time, by []string) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r.State = state
+	r.History = append(r.History, Transition{State: state, At: at, By: by})
+}
@@ -0,0 +1,125 @@
+package pending
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestFileStoreLifecycle(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state", "pending.db")
+	store := NewFileStore(path)
+
+	now := time.Now()
+	transfer := &PendingAction{Type: ActionTypeTransfer, Org: "org", Repo: "repo", IssueNumber: 1, Target: "org/other", ScheduledAt: now, ExpiresAt: now.Add(2 * time.Hour)}
+	closeAction := &PendingAction{Type: ActionTypeClose, Org: "org", Repo: "repo", IssueNumber: 2, ScheduledAt: now, ExpiresAt: now.Add(time.Hour)}
+	elsewhere := &PendingAction{Type: ActionTypeClose, Org: "org", Repo: "elsewhere", IssueNumber: 3, ScheduledAt: now, ExpiresAt: now}
+
+	for _, a := range []*PendingAction{transfer, closeAction, elsewhere} {
+		if err := store.Save(ctx, a); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	// A new store instance reads the same file
+	store = NewFileStore(path)
+
+	pending, err := store.Pending(ctx, "org", "repo")
+	if err != nil {
+		t.Fatalf("Pending() error = %v", err)
+	}
+	if len(pending) != 2 || pending[0].IssueNumber != 2 || pending[1].IssueNumber != 1 {
+		t.Fatalf("Pending() = %+v, want issues 2 then 1", pending)
+	}
+
+	if err := store.Transition(ctx, transfer, StateDecided, []string{"maintainer"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Transition(ctx, transfer, StateDecided, []string{"maintainer"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Transition(ctx, transfer, StateExecuted, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := store.Find(ctx, &models.Issue{Org: "org", Repo: "repo", Number: 1})
+	if err != nil || found != nil {
+		t.Errorf("Find() after execution = %+v, %v; want nil", found, err)
+	}
+	found, err = store.Find(ctx, &models.Issue{Org: "ORG", Repo: "repo", Number: 2})
+	if err != nil || found == nil || found.Type != ActionTypeClose {
+		t.Errorf("Find() = %+v, %v; want the pending close", found, err)
+	}
+
+	history, err := store.History(ctx, "org", "repo", 1)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("History() = %+v, %v", history, err)
+	}
+	var states []State
+	for _, tr := range history[0].History {
+		states = append(states, tr.State)
+	}
+	want := []State{StateScheduled, StateDecided, StateExecuted}
+	if len(states) != len(want) {
+		t.Fatalf("history states = %v, want %v", states, want)
+	}
+	for i := range want {
+		if states[i] != want[i] {
+			t.Errorf("history states = %v, want %v", states, want)
+		}
+	}
+	if by := history[0].History[1].By; len(by) != 1 || by[0] != "maintainer" {
+		t.Errorf("decided by = %v, want [maintainer]", by)
+	}
+
+	// Rescheduling after completion starts a new record
+	if err := store.Save(ctx, transfer); err != nil {
+		t.Fatal(err)
+	}
+	history, _ = store.History(ctx, "org", "repo", 1)
+	if len(history) != 2 || history[1].State != StateScheduled {
+		t.Errorf("History() after reschedule = %+v", history)
+	}
+}
+
+func TestFileStoreMissingFile(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "missing.db"))
+	pending, err := store.Pending(context.Background(), "org", "repo")
+	if err != nil || len(pending) != 0 {
+		t.Errorf("Pending() = %v, %v; want empty", pending, err)
+	}
+}
+
+func TestFileStoreConcurrentWriters(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "pending.db")
+
+	// Separate stores open the database separately, like separate processes
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(number int) {
+			defer wg.Done()
+			action := &PendingAction{Type: ActionTypeClose, Org: "org", Repo: "repo", IssueNumber: number, ScheduledAt: time.Now(), ExpiresAt: time.Now()}
+			errs <- NewFileStore(path).Save(ctx, action)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	pending, err := NewFileStore(path).Pending(ctx, "org", "repo")
+	if err != nil || len(pending) != 20 {
+		t.Errorf("Pending() = %d actions, %v; want all 20 saves kept", len(pending), err)
+	}
+}
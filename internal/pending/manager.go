@@ -2,20 +2,24 @@ package pending
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"log"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/Kavirubc/gh-simili/internal/audit"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
 const (
-	LabelPendingTransfer = "pending-transfer"
-	LabelPendingClose    = "pending-close"
-	metadataPattern      = `<!-- simili-pending-action: ({.*}) -->`
+	LabelPendingTransfer  = "pending-transfer"
+	LabelPendingClose     = "pending-close"
+	LabelPendingAction    = "pending-action"
+	LabelPendingNeedsInfo = "pending-needs-info"
+	metadataPattern       = `<!-- simili-pending-action: ({.*?})(?: sig:([0-9a-f]+))? -->`
 )
 
 var metadataRegex = regexp.MustCompile(`(?s)` + metadataPattern)
@@ -24,8 +28,10 @@ var metadataRegex = regexp.MustCompile(`(?s)` + metadataPattern)
 type ActionType string
 
 const (
-	ActionTypeTransfer ActionType = "transfer"
-	ActionTypeClose    ActionType = "close"
+	ActionTypeTransfer  ActionType = "transfer"
+	ActionTypeClose     ActionType = "close"
+	ActionTypeTriage    ActionType = "triage"     // delayed triage operations
+	ActionTypeNeedsInfo ActionType = "needs-info" // close unless the author replies
 )
 
 // PendingAction represents a scheduled action
@@ -39,172 +45,214 @@ type PendingAction struct {
 	ScheduledAt time.Time         `json:"scheduled_at"`
 	ExpiresAt   time.Time         `json:"expires_at"`
 	Metadata    map[string]string `json:"metadata,omitempty"`
+	Operations  []Operation       `json:"operations,omitempty"` // changes applied by a triage action
+
+	decided bool // a decision was already recorded in this run
+}
+
+// Operation is one change made by a delayed triage action. It mirrors
+// triage.Action, which cannot be imported here.
+type Operation struct {
+	Type     string `json:"type"`
+	Label    string `json:"label,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+	Reason   string `json:"reason,omitempty"`
 }
 
 // Manager handles pending actions
 type Manager struct {
-	gh  *github.Client
-	cfg *config.Config
+	gh         *github.Client
+	cfg        *config.Config
+	authorizer *Authorizer
+	store      Store
+	audit      *audit.Log
 }
 
 // NewManager creates a new pending action manager
 func NewManager(gh *github.Client, cfg *config.Config) *Manager {
-	return &Manager{
-		gh:  gh,
-		cfg: cfg,
+	m := &Manager{
+		gh:    gh,
+		cfg:   cfg,
+		store: NewCommentStore(gh, nil),
+	}
+	if cfg != nil {
+		m.store = NewCommentStore(gh, &cfg.Defaults.DelayedActions)
+		m.authorizer = NewAuthorizer(gh, &cfg.Defaults.DelayedActions)
+		m.audit = audit.New(cfg)
+		if cfg.Defaults.DelayedActions.Store == StoreFile {
+			m.store = NewFileStore(cfg.Defaults.DelayedActions.StorePath)
+		}
 	}
+	return m
+}
+
+// Decide checks the reactions on an action's comment, counting only users
+// allowed to approve or cancel it
+func (m *Manager) Decide(ctx context.Context, action *PendingAction) (*Decision, error) {
+	if m.authorizer == nil {
+		return nil, fmt.Errorf("delayed actions not configured")
+	}
+	decision, err := m.authorizer.Decide(ctx, action.Org, action.Repo, action.IssueNumber, action.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	if decision.Outcome != DecisionNone {
+		if err := m.Record(ctx, action, StateDecided, decision.DecidedBy); err != nil {
+			return nil, err
+		}
+	}
+	return decision, nil
 }
 
 // ScheduleTransfer schedules a transfer action
 func (m *Manager) ScheduleTransfer(ctx context.Context, issue *models.Issue, targetRepo string, commentID int, delayHours int) error {
-	// Add label (metadata is already in comment)
-	return m.gh.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{LabelPendingTransfer})
+	return m.schedule(ctx, issue, &PendingAction{Type: ActionTypeTransfer, Target: targetRepo, CommentID: commentID}, delayHours)
 }
 
 // ScheduleClose schedules a close action
 func (m *Manager) ScheduleClose(ctx context.Context, issue *models.Issue, originalIssueURL string, commentID int, delayHours int) error {
-	// Add label (metadata is already in comment)
-	return m.gh.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{LabelPendingClose})
+	return m.schedule(ctx, issue, &PendingAction{Type: ActionTypeClose, Target: originalIssueURL, CommentID: commentID}, delayHours)
 }
 
-// FindPendingActions finds all pending actions for issues with pending labels
-func (m *Manager) FindPendingActions(ctx context.Context, org, repo string) ([]*PendingAction, error) {
-	var actions []*PendingAction
-
-	// Find issues with pending-transfer label
-	transferIssues, err := m.gh.ListIssuesByLabel(ctx, org, repo, LabelPendingTransfer)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pending transfer issues: %w", err)
-	}
-
-	for _, issue := range transferIssues {
-		action, err := m.extractPendingAction(ctx, issue, ActionTypeTransfer)
-		if err == nil && action != nil {
-			actions = append(actions, action)
-		}
-	}
-
-	// Find issues with pending-close label
-	closeIssues, err := m.gh.ListIssuesByLabel(ctx, org, repo, LabelPendingClose)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list pending close issues: %w", err)
-	}
-
-	for _, issue := range closeIssues {
-		action, err := m.extractPendingAction(ctx, issue, ActionTypeClose)
-		if err == nil && action != nil {
-			actions = append(actions, action)
-		}
-	}
+// ScheduleTriage schedules triage operations. A newer triage action on the
+// same issue replaces the older one.
+func (m *Manager) ScheduleTriage(ctx context.Context, issue *models.Issue, operations []Operation, commentID int, delayHours int) error {
+	return m.schedule(ctx, issue, &PendingAction{Type: ActionTypeTriage, Operations: operations, CommentID: commentID}, delayHours)
+}
 
-	return actions, nil
+// ScheduleNeedsInfoClose schedules closing an issue that is waiting on its author
+func (m *Manager) ScheduleNeedsInfoClose(ctx context.Context, issue *models.Issue, commentID int, delayHours int) error {
+	return m.schedule(ctx, issue, &PendingAction{Type: ActionTypeNeedsInfo, CommentID: commentID}, delayHours)
 }
 
-// extractPendingAction extracts pending action from issue comments
-func (m *Manager) extractPendingAction(ctx context.Context, issue *models.Issue, actionType ActionType) (*PendingAction, error) {
-	comments, err := m.gh.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+// schedule labels the issue and saves the action (the metadata is already in the comment)
+func (m *Manager) schedule(ctx context.Context, issue *models.Issue, action *PendingAction, delayHours int) error {
+	label, err := labelFor(action.Type)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if err := m.gh.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{label}); err != nil {
+		return err
 	}
 
-	for _, comment := range comments {
-		matches := metadataRegex.FindStringSubmatch(comment.Body)
-		if len(matches) < 2 {
-			continue
-		}
-
-		var action PendingAction
-		if err := json.Unmarshal([]byte(matches[1]), &action); err != nil {
-			continue
-		}
-
-		if action.Type == actionType && action.IssueNumber == issue.Number {
-			action.Org = issue.Org
-			action.Repo = issue.Repo
-			action.CommentID = comment.ID
-			return &action, nil
-		}
+	now := time.Now()
+	action.Org = issue.Org
+	action.Repo = issue.Repo
+	action.IssueNumber = issue.Number
+	action.ScheduledAt = now
+	action.ExpiresAt = now.Add(time.Duration(delayHours) * time.Hour)
+	if err := m.store.Save(ctx, action); err != nil {
+		return err
 	}
+	m.record(action, audit.OutcomeScheduled, nil)
+	return nil
+}
 
-	return nil, fmt.Errorf("pending action not found")
+// FindPendingActions finds all open pending actions of a repository
+func (m *Manager) FindPendingActions(ctx context.Context, org, repo string) ([]*PendingAction, error) {
+	return m.store.Pending(ctx, org, repo)
 }
 
 // GetPendingAction gets the pending action for a specific issue
 func (m *Manager) GetPendingAction(ctx context.Context, issue *models.Issue) (*PendingAction, error) {
-	// Check if issue has pending labels
-	hasTransfer := false
-	hasClose := false
+	return m.store.Find(ctx, issue)
+}
 
-	for _, label := range issue.Labels {
-		if label == LabelPendingTransfer {
-			hasTransfer = true
-		}
-		if label == LabelPendingClose {
-			hasClose = true
+// Validate refuses an action whose target is not a configured repository and
+// cancels it so it is not retried
+func (m *Manager) Validate(ctx context.Context, action *PendingAction) error {
+	if m.cfg == nil {
+		return nil
+	}
+	if err := ValidateTarget(m.cfg, action); err != nil {
+		log.Printf("Warning: cancelling pending %s on %s/%s#%d: %v", action.Type, action.Org, action.Repo, action.IssueNumber, err)
+		if cancelErr := m.Cancel(ctx, action); cancelErr != nil {
+			log.Printf("Warning: failed to cancel pending action: %v", cancelErr)
 		}
+		return err
 	}
+	return nil
+}
 
-	if !hasTransfer && !hasClose {
-		return nil, nil // No pending action
+// Record moves an action to a new lifecycle state. A decision already
+// recorded for the action is not recorded again.
+func (m *Manager) Record(ctx context.Context, action *PendingAction, state State, by []string) error {
+	if state == StateDecided && action.decided {
+		return nil
 	}
-
-	if hasTransfer {
-		action, err := m.extractPendingAction(ctx, issue, ActionTypeTransfer)
-		if err == nil && action != nil {
-			return action, nil
-		}
+	if err := m.store.Transition(ctx, action, state, by); err != nil {
+		return err
 	}
-
-	if hasClose {
-		action, err := m.extractPendingAction(ctx, issue, ActionTypeClose)
-		if err == nil && action != nil {
-			return action, nil
-		}
+	if state == StateDecided {
+		action.decided = true
 	}
+	m.record(action, string(state), by)
+	return nil
+}
 
-	return nil, nil
+// IsExpired checks if action has expired
+func (a *PendingAction) IsExpired() bool {
+	return time.Now().After(a.ExpiresAt)
 }
 
-// FormatPendingActionMetadata formats action metadata as HTML comment
-func FormatPendingActionMetadata(action *PendingAction) (string, error) {
-	data, err := json.Marshal(action)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal pending action: %w", err)
-	}
-	return fmt.Sprintf("<!-- simili-pending-action: %s -->", string(data)), nil
+// Cancel removes pending label and cancels the action
+func (m *Manager) Cancel(ctx context.Context, action *PendingAction) error {
+	return m.finish(ctx, action, StateCancelled)
 }
 
-// ParsePendingActionMetadata parses action metadata from comment body
-func ParsePendingActionMetadata(commentBody string) (*PendingAction, error) {
-	matches := metadataRegex.FindStringSubmatch(commentBody)
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("metadata not found")
-	}
+// Complete removes the pending label of an action that has been carried out
+func (m *Manager) Complete(ctx context.Context, action *PendingAction) error {
+	return m.finish(ctx, action, StateExecuted)
+}
 
-	var action PendingAction
-	if err := json.Unmarshal([]byte(matches[1]), &action); err != nil {
-		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+// finish records the final state and removes the pending label
+func (m *Manager) finish(ctx context.Context, action *PendingAction, state State) error {
+	label, err := labelFor(action.Type)
+	if err != nil {
+		return err
 	}
 
-	return &action, nil
+	if err := m.store.Transition(ctx, action, state, nil); err != nil {
+		return err
+	}
+	m.record(action, string(state), nil)
+	return m.gh.RemoveLabel(ctx, action.Org, action.Repo, action.IssueNumber, label)
 }
 
-// IsExpired checks if action has expired
-func (a *PendingAction) IsExpired() bool {
-	return time.Now().After(a.ExpiresAt)
+// record writes a lifecycle change to the audit log
+func (m *Manager) record(action *PendingAction, outcome string, by []string) {
+	target := action.Target
+	if action.Type == ActionTypeTriage {
+		types := make([]string, len(action.Operations))
+		for i, op := range action.Operations {
+			types[i] = op.Type
+		}
+		target = strings.Join(types, ",")
+	}
+	m.audit.Record(audit.Entry{
+		Org:     action.Org,
+		Repo:    action.Repo,
+		Issue:   action.IssueNumber,
+		Action:  "pending_" + string(action.Type),
+		Target:  target,
+		Actor:   audit.Actor(by),
+		Outcome: outcome,
+	})
 }
 
-// Cancel removes pending label and cancels the action
-func (m *Manager) Cancel(ctx context.Context, action *PendingAction) error {
-	var label string
-	switch action.Type {
+// labelFor returns the label marking an issue with a pending action
+func labelFor(actionType ActionType) (string, error) {
+	switch actionType {
 	case ActionTypeTransfer:
-		label = LabelPendingTransfer
+		return LabelPendingTransfer, nil
 	case ActionTypeClose:
-		label = LabelPendingClose
+		return LabelPendingClose, nil
+	case ActionTypeTriage:
+		return LabelPendingAction, nil
+	case ActionTypeNeedsInfo:
+		return LabelPendingNeedsInfo, nil
 	default:
-		return fmt.Errorf("unknown action type: %s", action.Type)
+		return "", fmt.Errorf("unknown action type: %s", actionType)
 	}
-
-	return m.gh.RemoveLabel(ctx, action.Org, action.Repo, action.IssueNumber, label)
 }
@@ -0,0 +1,104 @@
+package pending
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+)
+
+// ErrInvalidSignature is returned for metadata that is unsigned or was
+// modified after it was signed
+var ErrInvalidSignature = errors.New("invalid pending action signature")
+
+// FormatPendingActionMetadata formats action metadata as HTML comment. With a
+// secret, an HMAC-SHA256 signature of the JSON is appended.
+func FormatPendingActionMetadata(action *PendingAction, secret string) (string, error) {
+	data, err := json.Marshal(action)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pending action: %w", err)
+	}
+	if secret == "" {
+		return fmt.Sprintf("<!-- simili-pending-action: %s -->", string(data)), nil
+	}
+	return fmt.Sprintf("<!-- simili-pending-action: %s sig:%s -->", string(data), Sign(data, secret)), nil
+}
+
+// ParsePendingActionMetadata parses action metadata from comment body. With a
+// secret, the signature must match.
+func ParsePendingActionMetadata(commentBody string, secret string) (*PendingAction, error) {
+	matches := metadataRegex.FindStringSubmatch(commentBody)
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("metadata not found")
+	}
+
+	if secret != "" && !hmac.Equal([]byte(matches[2]), []byte(Sign([]byte(matches[1]), secret))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var action PendingAction
+	if err := json.Unmarshal([]byte(matches[1]), &action); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return &action, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of data
+func Sign(data []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateTarget checks that an action only points at configured
+// repositories: a transfer target must be another configured repository and
+// the original issue of a close must live in one. Triage actions must carry
+// at least one operation.
+func ValidateTarget(cfg *config.Config, action *PendingAction) error {
+	switch action.Type {
+	case ActionTypeTransfer:
+		org, repo, err := github.ParseRepo(action.Target)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(org, action.Org) && strings.EqualFold(repo, action.Repo) {
+			return fmt.Errorf("transfer target %s is the issue's own repository", action.Target)
+		}
+		if !configuredRepo(cfg, org, repo) {
+			return fmt.Errorf("transfer target %s is not a configured repository", action.Target)
+		}
+	case ActionTypeClose:
+		org, repo, _, err := github.ParseIssueURL(action.Target)
+		if err != nil {
+			return err
+		}
+		if !configuredRepo(cfg, org, repo) {
+			return fmt.Errorf("original issue %s is not in a configured repository", action.Target)
+		}
+	case ActionTypeTriage:
+		if len(action.Operations) == 0 {
+			return fmt.Errorf("triage action has no operations")
+		}
+	case ActionTypeNeedsInfo:
+	default:
+		return fmt.Errorf("unknown action type: %s", action.Type)
+	}
+	return nil
+}
+
+// configuredRepo reports whether a repository is listed in the config
+func configuredRepo(cfg *config.Config, org, repo string) bool {
+	for _, r := range cfg.Repositories {
+		if strings.EqualFold(r.Org, org) && strings.EqualFold(r.Repo, repo) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package pending
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestPendingActionMetadataSignature(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	action := &PendingAction{Type: ActionTypeTransfer, Org: "org", Repo: "repo", IssueNumber: 7, Target: "org/other", ScheduledAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	signed, err := FormatPendingActionMetadata(action, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsigned, err := FormatPendingActionMetadata(action, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		secret  string
+		wantErr error
+	}{
+		{"signed round trip", "Scheduled.\n\n" + signed, "secret", nil},
+		{"unsigned without secret", unsigned, "", nil},
+		{"signed without secret", signed, "", nil},
+		{"wrong secret", signed, "other", ErrInvalidSignature},
+		{"unsigned with secret", unsigned, "secret", ErrInvalidSignature},
+		{"tampered target", strings.Replace(signed, "org/other", "evil/repo", 1), "secret", ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePendingActionMetadata(tt.body, tt.secret)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParsePendingActionMetadata() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.Target != action.Target || got.IssueNumber != action.IssueNumber) {
+				t.Errorf("ParsePendingActionMetadata() = %+v, want %+v", got, action)
+			}
+		})
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.RepositoryConfig{
+		{Org: "org", Repo: "repo"},
+		{Org: "org", Repo: "other"},
+	}}
+
+	tests := []struct {
+		name    string
+		action  PendingAction
+		wantErr bool
+	}{
+		{"configured transfer target", PendingAction{Type: ActionTypeTransfer, Org: "org", Repo: "repo", Target: "Org/Other"}, false},
+		{"unconfigured transfer target", PendingAction{Type: ActionTypeTransfer, Org: "org", Repo: "repo", Target: "evil/repo"}, true},
+		{"transfer to own repo", PendingAction{Type: ActionTypeTransfer, Org: "org", Repo: "repo", Target: "org/repo"}, true},
+		{"close with configured original", PendingAction{Type: ActionTypeClose, Org: "org", Repo: "repo", Target: "https://github.com/org/other/issues/3"}, false},
+		{"close with unconfigured original", PendingAction{Type: ActionTypeClose, Org: "org", Repo: "repo", Target: "https://github.com/evil/repo/issues/3"}, true},
+		{"unknown type", PendingAction{Type: "delete", Org: "org", Repo: "repo"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTarget(cfg, &tt.action); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
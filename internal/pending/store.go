@@ -0,0 +1,55 @@
+package pending
+
+import (
+	"context"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// State is a step in a pending action's lifecycle
+type State string
+
+const (
+	StateScheduled State = "scheduled"
+	StateDecided   State = "decided"
+	StateExecuted  State = "executed"
+	StateCancelled State = "cancelled"
+)
+
+// IsTerminal reports whether no further transitions are expected
+func (s State) IsTerminal() bool {
+	return s == StateExecuted || s == StateCancelled
+}
+
+// Transition records when an action entered a state and who caused it
+type Transition struct {
+	State State     `json:"state"`
+	At    time.Time `json:"at"`
+	By    []string  `json:"by,omitempty"`
+}
+
+// Record is a pending action together with its lifecycle history
+type Record struct {
+	Action  PendingAction `json:"action"`
+	State   State         `json:"state"`
+	History []Transition  `json:"history"`
+}
+
+// Store persists pending actions
+type Store interface {
+	// Save records a newly scheduled action
+	Save(ctx context.Context, action *PendingAction) error
+	// Transition moves an action to a new state; repeating the current state is a no-op
+	Transition(ctx context.Context, action *PendingAction, state State, by []string) error
+	// Pending lists the actions of a repository that are not executed or cancelled yet
+	Pending(ctx context.Context, org, repo string) ([]*PendingAction, error)
+	// Find returns the open action of an issue, or nil
+	Find(ctx context.Context, issue *models.Issue) (*PendingAction, error)
+}
+
+// Store backends
+const (
+	StoreComments = "comments"
+	StoreFile     = "file"
+)
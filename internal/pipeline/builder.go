@@ -7,6 +7,7 @@ package pipeline
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
@@ -14,6 +15,8 @@ import (
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/steps"
 	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/rerank"
+	"github.com/Kavirubc/gh-simili/internal/transfer"
 	"github.com/Kavirubc/gh-simili/internal/triage"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
 )
@@ -28,6 +31,7 @@ type Builder struct {
 	indexer        *processor.Indexer
 	triageAgent    *triage.Agent
 	llm            llm.Provider
+	reranker       rerank.Reranker
 	dryRun         bool
 	execute        bool
 }
@@ -45,6 +49,11 @@ func NewBuilder(
 	dryRun bool,
 	execute bool,
 ) *Builder {
+	reranker, err := rerank.New(&cfg.Rerank, llmProvider)
+	if err != nil {
+		log.Printf("Warning: re-ranking disabled: %v", err)
+	}
+
 	return &Builder{
 		cfg:            cfg,
 		gh:             gh,
@@ -54,21 +63,32 @@ func NewBuilder(
 		indexer:        indexer,
 		triageAgent:    triageAgent,
 		llm:            llmProvider,
+		reranker:       reranker,
 		dryRun:         dryRun,
 		execute:        execute,
 	}
 }
 
+// repoScorer returns the similarity finder as a repository scorer, or nil
+// when there is none
+func (b *Builder) repoScorer() triage.RepoScorer {
+	if b.similarity == nil {
+		return nil
+	}
+	return b.similarity
+}
+
 // BuildDefault creates the standard pipeline
 func (b *Builder) BuildDefault() []core.Step {
 	return []core.Step{
 		steps.NewRepoGatekeeper(b.gh),
 		steps.NewVectorDBPrep(b.vdb, b.dryRun),
 		steps.NewSimilaritySearch(b.similarity),
-		steps.NewTransferCheck(b.llm, b.gh),
+		steps.NewRerank(b.reranker, b.gh, b.cfg.Rerank.TopK),
+		steps.NewTransferCheck(b.llm, b.gh, b.repoScorer()),
 		steps.NewTriageAnalysis(b.triageAgent),
 		steps.NewResponseBuilder(),
-		steps.NewActionExecutor(b.gh, b.transferClient, b.vdb, b.dryRun, b.execute),
+		steps.NewActionExecutor(b.gh, b.vdb, b.transferExecutor, b.dryRun, b.execute),
 		steps.NewIndexer(b.indexer, b.dryRun),
 	}
 }
@@ -99,17 +119,25 @@ func (b *Builder) createStep(name string) (core.Step, error) {
 		return steps.NewVectorDBPrep(b.vdb, b.dryRun), nil
 	case "similarity_search":
 		return steps.NewSimilaritySearch(b.similarity), nil
+	case "rerank":
+		return steps.NewRerank(b.reranker, b.gh, b.cfg.Rerank.TopK), nil
 	case "transfer_check":
-		return steps.NewTransferCheck(b.llm, b.gh), nil
+		return steps.NewTransferCheck(b.llm, b.gh, b.repoScorer()), nil
 	case "triage":
 		return steps.NewTriageAnalysis(b.triageAgent), nil
 	case "response_builder":
 		return steps.NewResponseBuilder(), nil
 	case "action_executor":
-		return steps.NewActionExecutor(b.gh, b.transferClient, b.vdb, b.dryRun, b.execute), nil
+		return steps.NewActionExecutor(b.gh, b.vdb, b.transferExecutor, b.dryRun, b.execute), nil
 	case "indexer":
 		return steps.NewIndexer(b.indexer, b.dryRun), nil
 	default:
 		return nil, fmt.Errorf("unknown step: %s", name)
 	}
 }
+
+// transferExecutor creates the transfer executor of the action step, wired
+// like every other transfer
+func (b *Builder) transferExecutor() *transfer.Executor {
+	return newTransferExecutor(b.cfg, b.transferClient, b.gh, b.vdb, b.indexer, b.triageAgent, b.dryRun)
+}
@@ -0,0 +1,258 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/command"
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/pipeline/steps"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Reactions used to acknowledge a command comment
+const (
+	reactionDone     = "+1"
+	reactionRejected = "confused"
+)
+
+// errNoPendingAction is returned by approve/cancel when nothing is scheduled
+var errNoPendingAction = errors.New("there is no pending action on this issue")
+
+// processCommands runs the slash commands of a comment written by a user with
+// enough repository permission, then acknowledges the comment
+func (up *UnifiedProcessor) processCommands(ctx context.Context, issue *models.Issue, comment *github.EventComment, commands []command.Command) (*core.UnifiedResult, error) {
+	result := &core.UnifiedResult{IssueNumber: issue.Number}
+
+	user := ""
+	if comment.User != nil {
+		user = comment.User.Login
+		if comment.User.Type == "Bot" {
+			result.Skipped = true
+			result.SkipReason = "commands from bots are ignored"
+			return result, nil
+		}
+	}
+
+	allowed, err := up.commandAllowed(ctx, issue, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check command permission: %w", err)
+	}
+	if !allowed {
+		log.Printf("Ignoring commands from @%s on issue #%d: %s permission required", user, issue.Number, up.cfg.Commands.MinPermission)
+		up.acknowledge(ctx, issue, comment.ID, reactionRejected)
+		result.Skipped = true
+		result.SkipReason = fmt.Sprintf("@%s may not run commands", user)
+		return result, nil
+	}
+
+	var failures []string
+	for _, cmd := range commands {
+		result.Commands = append(result.Commands, cmd.String())
+
+		err := cmd.Validate()
+		if err == nil {
+			err = up.runCommand(ctx, issue, user, cmd, result)
+		}
+		if err != nil {
+			log.Printf("Command %q on issue #%d failed: %v", cmd.String(), issue.Number, err)
+			failures = append(failures, fmt.Sprintf("- `%s %s`: %v", up.cfg.Commands.Prefix, cmd, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		up.acknowledge(ctx, issue, comment.ID, reactionRejected)
+		up.reply(ctx, issue, "⚠️ Some commands could not be run:\n\n"+strings.Join(failures, "\n"))
+	} else {
+		up.acknowledge(ctx, issue, comment.ID, reactionDone)
+	}
+
+	return result, nil
+}
+
+// runCommand executes a single validated command
+func (up *UnifiedProcessor) runCommand(ctx context.Context, issue *models.Issue, user string, cmd command.Command, result *core.UnifiedResult) error {
+	switch cmd.Name {
+	case command.Approve, command.Cancel:
+		return up.decidePendingAction(ctx, issue, user, cmd.Name == command.Approve, result)
+
+	case command.Transfer:
+		target := cmd.Args[0]
+		executor := up.TransferExecutor()
+		if err := executor.TransferNow(ctx, issue, target, user); err != nil {
+			return err
+		}
+		result.TransferTarget = target
+		result.Transferred = true
+		result.ActionsExecuted++
+		return nil
+
+	case command.NotDuplicate:
+		return up.markNotDuplicate(ctx, issue, result)
+
+	case command.Retriage:
+		full, err := up.gh.GetIssue(ctx, issue.Org, issue.Repo, issue.Number)
+		if err != nil {
+			return err
+		}
+		res, err := up.processIssue(ctx, full, true)
+		if err != nil {
+			return err
+		}
+		result.SimilarFound = res.SimilarFound
+		result.TriageResult = res.TriageResult
+		result.TransferTarget = res.TransferTarget
+		result.Transferred = res.Transferred
+		result.CommentPosted = res.CommentPosted
+		result.Indexed = res.Indexed
+		result.ActionsExecuted += res.ActionsExecuted
+		return nil
+
+	case command.Similar:
+		similar, err := up.similarity.FindSimilar(ctx, issue, true)
+		if err != nil {
+			return fmt.Errorf("similarity search failed: %w", err)
+		}
+		result.SimilarFound = similar
+		if len(similar) == 0 {
+			up.reply(ctx, issue, "No similar issues found.")
+			return nil
+		}
+		up.reply(ctx, issue, steps.FormatRelatedIssues(issue, similar))
+		return nil
+	}
+
+	return fmt.Errorf("unknown command `%s`", cmd.Name)
+}
+
+// decidePendingAction approves or cancels the issue's pending transfer or close
+func (up *UnifiedProcessor) decidePendingAction(ctx context.Context, issue *models.Issue, user string, approve bool, result *core.UnifiedResult) error {
+	action, err := pending.NewManager(up.gh, up.cfg).GetPendingAction(ctx, issue)
+	if err != nil {
+		return err
+	}
+	if action == nil {
+		return errNoPendingAction
+	}
+	result.PendingAction = action
+
+	switch action.Type {
+	case pending.ActionTypeTransfer:
+		executor := up.TransferExecutor()
+		if !approve {
+			return executor.CancelPendingTransfer(ctx, action, []string{user})
+		}
+		if err := executor.ApprovePendingTransfer(ctx, action, []string{user}); err != nil {
+			return err
+		}
+		result.TransferTarget = action.Target
+		result.Transferred = true
+
+	case pending.ActionTypeClose:
+		dChecker := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&up.cfg.Triage.Duplicate, up.gh, up.cfg, up.dryRun)
+		if !approve {
+			return dChecker.CancelPendingClose(ctx, action, []string{user})
+		}
+		if err := dChecker.ApprovePendingClose(ctx, action, []string{user}); err != nil {
+			return err
+		}
+
+	case pending.ActionTypeTriage, pending.ActionTypeNeedsInfo:
+		executor := up.triageExecutor()
+		if !approve {
+			return executor.CancelPendingAction(ctx, action, []string{user})
+		}
+		if err := executor.ApprovePendingAction(ctx, action, []string{user}); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown pending action type: %s", action.Type)
+	}
+
+	result.ActionsExecuted++
+	return nil
+}
+
+// markNotDuplicate cancels a pending close, removes the duplicate labels and
+// reopens an issue that was closed as a duplicate
+func (up *UnifiedProcessor) markNotDuplicate(ctx context.Context, issue *models.Issue, result *core.UnifiedResult) error {
+	if up.dryRun {
+		return nil
+	}
+
+	pendingMgr := pending.NewManager(up.gh, up.cfg)
+	action, err := pendingMgr.GetPendingAction(ctx, issue)
+	if err != nil {
+		return err
+	}
+	if action != nil && action.Type == pending.ActionTypeClose {
+		if err := pendingMgr.Cancel(ctx, action); err != nil {
+			return err
+		}
+	}
+
+	closedAsDuplicate := false
+	for _, label := range issue.Labels {
+		if !strings.EqualFold(label, "duplicate") && !strings.EqualFold(label, "potential-duplicate") {
+			continue
+		}
+		if strings.EqualFold(label, "duplicate") {
+			closedAsDuplicate = true
+		}
+		if err := up.gh.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, label); err != nil {
+			return err
+		}
+	}
+
+	if closedAsDuplicate && issue.State == "closed" {
+		if err := up.gh.ReopenIssue(ctx, issue.Org, issue.Repo, issue.Number); err != nil {
+			return err
+		}
+	}
+
+	result.ActionsExecuted++
+	return nil
+}
+
+// commandAllowed checks the commenter's repository permission
+func (up *UnifiedProcessor) commandAllowed(ctx context.Context, issue *models.Issue, user string) (bool, error) {
+	if user == "" {
+		return false, nil
+	}
+	permission, err := up.gh.CollaboratorPermission(ctx, issue.Org, issue.Repo, user)
+	if err != nil {
+		return false, err
+	}
+	rank := config.PermissionRank(permission)
+	return rank >= 0 && rank >= config.PermissionRank(up.cfg.Commands.MinPermission), nil
+}
+
+// acknowledge reacts to the command comment
+func (up *UnifiedProcessor) acknowledge(ctx context.Context, issue *models.Issue, commentID int, reaction string) {
+	if up.dryRun {
+		return
+	}
+	if err := up.gh.AddCommentReaction(ctx, issue.Org, issue.Repo, commentID, reaction); err != nil {
+		log.Printf("Warning: failed to acknowledge command on issue #%d: %v", issue.Number, err)
+	}
+}
+
+// reply answers a command with a comment
+func (up *UnifiedProcessor) reply(ctx context.Context, issue *models.Issue, body string) {
+	if up.dryRun {
+		log.Printf("[DRY RUN] Would reply on issue #%d:\n%s", issue.Number, body)
+		return
+	}
+	body += "\n\n---\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>"
+	if err := up.gh.PostComment(ctx, issue.Org, issue.Repo, issue.Number, body); err != nil {
+		log.Printf("Warning: failed to reply to command on issue #%d: %v", issue.Number, err)
+	}
+}
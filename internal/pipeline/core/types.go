@@ -22,17 +22,19 @@ var ErrSkipPipeline = errors.New("skip pipeline")
 
 // UnifiedResult contains the complete result of unified processing
 type UnifiedResult struct {
-	IssueNumber     int                     `json:"issue_number"`
-	Skipped         bool                    `json:"skipped,omitempty"`
-	SkipReason      string                  `json:"skip_reason,omitempty"`
-	SimilarFound    []vectordb.SearchResult `json:"similar_found,omitempty"`
-	TriageResult    *triage.Result          `json:"triage_result,omitempty"`
-	Transferred     bool                    `json:"transferred,omitempty"`
-	TransferTarget  string                  `json:"transfer_target,omitempty"`
-	CommentPosted   bool                    `json:"comment_posted,omitempty"`
-	Indexed         bool                    `json:"indexed,omitempty"`
-	ActionsExecuted int                     `json:"actions_executed,omitempty"`
-	PendingAction   *pending.PendingAction  `json:"pending_action,omitempty"`
+	IssueNumber       int                     `json:"issue_number"`
+	Skipped           bool                    `json:"skipped,omitempty"`
+	SkipReason        string                  `json:"skip_reason,omitempty"`
+	SimilarFound      []vectordb.SearchResult `json:"similar_found,omitempty"`
+	TriageResult      *triage.Result          `json:"triage_result,omitempty"`
+	Transferred       bool                    `json:"transferred,omitempty"`
+	TransferTarget    string                  `json:"transfer_target,omitempty"`
+	SuggestedTransfer string                  `json:"suggested_transfer,omitempty"` // router suggestion in suggest-only mode
+	CommentPosted     bool                    `json:"comment_posted,omitempty"`
+	Indexed           bool                    `json:"indexed,omitempty"`
+	ActionsExecuted   int                     `json:"actions_executed,omitempty"`
+	PendingAction     *pending.PendingAction  `json:"pending_action,omitempty"`
+	Commands          []string                `json:"commands,omitempty"` // slash commands run from a comment
 }
 
 // Context carries state through the pipeline steps.
@@ -53,6 +55,12 @@ type Context struct {
 	// TransferTarget holds the matched transfer target repo name (if any)
 	TransferTarget string
 
+	// TransferRouting holds the router's decision when it chose TransferTarget
+	TransferRouting *triage.RoutingResult
+
+	// SuggestedTransfer holds the router's suggestion when routing is suggest-only
+	SuggestedTransfer *triage.RoutingResult
+
 	// TriageResult holds the output of the LLM/Rule-based triage
 	TriageResult *triage.Result
 
@@ -61,6 +69,9 @@ type Context struct {
 
 	// SkipReason is set when ErrSkipPipeline is returned to explain why
 	SkipReason string
+
+	// Force re-processes an issue that was already commented on (e.g. /simili retriage)
+	Force bool
 }
 
 // Step defines a single unit of work in the pipeline.
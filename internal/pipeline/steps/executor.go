@@ -8,6 +8,7 @@ package steps
 import (
 	"log"
 
+	"github.com/Kavirubc/gh-simili/internal/cluster"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/internal/transfer"
@@ -16,20 +17,20 @@ import (
 )
 
 type ActionExecutor struct {
-	gh             *github.Client
-	transferClient *github.Client
-	vdb            *vectordb.Client
-	dryRun         bool
-	runActions     bool // "execute" flag in old unified.go
+	gh          *github.Client
+	vdb         *vectordb.Client
+	newTransfer func() *transfer.Executor // the pipeline's shared transfer executor constructor
+	dryRun      bool
+	runActions  bool // "execute" flag in old unified.go
 }
 
-func NewActionExecutor(gh *github.Client, transferClient *github.Client, vdb *vectordb.Client, dryRun bool, runActions bool) *ActionExecutor {
+func NewActionExecutor(gh *github.Client, vdb *vectordb.Client, newTransfer func() *transfer.Executor, dryRun bool, runActions bool) *ActionExecutor {
 	return &ActionExecutor{
-		gh:             gh,
-		transferClient: transferClient,
-		vdb:            vdb,
-		dryRun:         dryRun,
-		runActions:     runActions,
+		gh:          gh,
+		vdb:         vdb,
+		newTransfer: newTransfer,
+		dryRun:      dryRun,
+		runActions:  runActions,
 	}
 }
 
@@ -65,15 +66,43 @@ func (s *ActionExecutor) Run(ctx *core.Context) error {
 		s.executeTriageRequest(ctx, commentID)
 	}
 
+	// 4. Record duplicate cluster membership
+	if ctx.Config.Clustering.Enabled && ctx.TriageResult != nil {
+		s.joinCluster(ctx)
+	}
+
 	return nil
 }
 
+func (s *ActionExecutor) joinCluster(ctx *core.Context) {
+	dup := ctx.TriageResult.Duplicate
+	if dup == nil || !dup.IsDuplicate || dup.Original == nil {
+		return
+	}
+
+	match := dup.Original
+	if dup.Matched != nil {
+		match = dup.Matched
+	}
+
+	// Sets ctx.Issue.ClusterID so the indexer step stores it with the issue
+	manager := cluster.NewManager(s.vdb, &ctx.Config.Clustering)
+	if _, err := manager.Join(ctx.Ctx, ctx.Issue, match); err != nil {
+		log.Printf("Warning: failed to record duplicate cluster: %v", err)
+	}
+}
+
 func (s *ActionExecutor) executeTransfer(ctx *core.Context, commentID int) {
-	executor := transfer.NewExecutor(s.transferClient, s.gh, s.vdb, ctx.Config, s.dryRun)
+	executor := s.newTransfer()
+
+	var routing *transfer.Routing
+	if ctx.TransferRouting != nil {
+		routing = &transfer.Routing{Confidence: ctx.TransferRouting.Confidence, Reason: ctx.TransferRouting.Reason}
+	}
 
 	// Optimistic?
 	if ctx.Config.Defaults.DelayedActions.Enabled && ctx.Config.Defaults.DelayedActions.OptimisticTransfers {
-		if err := executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil); err != nil { // nil rule? we lost the rule obj in Context, but maybe Transfer doesn't NEED it if target is set?
+		if err := executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil, routing); err != nil { // nil rule? we lost the rule obj in Context, but maybe Transfer doesn't NEED it if target is set?
 			// Checking transfer.go: Transfer(ctx, issue, target, rule). The rule is used for logging priority.
 			// Currently we didn't store the rule in Context, only the target.
 			// That's acceptable for now.
@@ -89,7 +118,7 @@ func (s *ActionExecutor) executeTransfer(ctx *core.Context, commentID int) {
 		}
 	} else {
 		// Fallback
-		if err := executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil); err != nil {
+		if err := executor.Transfer(ctx.Ctx, ctx.Issue, ctx.TransferTarget, nil, routing); err != nil {
 			log.Printf("Warning: failed to transfer: %v", err)
 		} else {
 			ctx.Result.Transferred = true
@@ -117,7 +146,7 @@ func (s *ActionExecutor) executeTriageRequest(ctx *core.Context, commentID int)
 		}
 		executor = triage.NewExecutorWithDelayedActions(s.gh, ctx.Config, dupChecker, s.dryRun)
 	} else {
-		executor = triage.NewExecutor(s.gh, s.dryRun)
+		executor = triage.NewExecutor(s.gh, ctx.Config, s.dryRun)
 	}
 
 	filteredResult := *ctx.TriageResult // Copy
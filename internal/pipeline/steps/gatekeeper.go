@@ -42,7 +42,10 @@ func (s *RepoGatekeeper) Run(ctx *core.Context) error {
 		return core.ErrSkipPipeline
 	}
 
-	// 2. Check cooldown
+	// 2. Check cooldown (unless re-processing was requested)
+	if ctx.Force {
+		return nil
+	}
 	skip, err := s.gh.ShouldSkipComment(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number, ctx.Config.Defaults.CommentCooldownHours)
 	if err != nil {
 		return fmt.Errorf("failed to check cooldown: %w", err)
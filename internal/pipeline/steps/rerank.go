@@ -0,0 +1,91 @@
+// Author: Kaviru Hapuarachchi
+// GitHub: https://github.com/Kavirubc
+// Created: 2026-10-18
+// Last Modified: 2026-10-18
+
+package steps
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
+	"github.com/Kavirubc/gh-simili/internal/rerank"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Rerank re-scores similarity candidates and drops unrelated ones.
+type Rerank struct {
+	reranker rerank.Reranker
+	gh       IssueFetcher
+	topK     int
+}
+
+// IssueFetcher defines the subset of github.Client needed to load candidate bodies
+type IssueFetcher interface {
+	GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error)
+}
+
+// NewRerank creates a new re-ranking step. A nil reranker makes the step a no-op.
+func NewRerank(reranker rerank.Reranker, gh IssueFetcher, topK int) *Rerank {
+	return &Rerank{
+		reranker: reranker,
+		gh:       gh,
+		topK:     topK,
+	}
+}
+
+func (s *Rerank) Name() string {
+	return "rerank"
+}
+
+func (s *Rerank) Run(ctx *core.Context) error {
+	if s.reranker == nil || len(ctx.SimilarIssues) == 0 {
+		return nil
+	}
+
+	// Vector payloads only carry titles; load bodies into a copy so the
+	// reranker can compare content without the bodies leaking into the results
+	candidates := make([]vectordb.SearchResult, len(ctx.SimilarIssues))
+	copy(candidates, ctx.SimilarIssues)
+	originalBodies := make(map[string]string, len(candidates))
+	for _, c := range ctx.SimilarIssues {
+		originalBodies[candidateKey(c.Issue)] = c.Issue.Body
+	}
+	if s.gh != nil {
+		for i := range candidates {
+			if i >= s.topK && s.topK > 0 {
+				break
+			}
+			full, err := s.gh.GetIssue(ctx.Ctx, candidates[i].Issue.Org, candidates[i].Issue.Repo, candidates[i].Issue.Number)
+			if err != nil {
+				log.Printf("Warning: failed to load candidate #%d for re-ranking: %v", candidates[i].Issue.Number, err)
+				continue
+			}
+			candidates[i].Issue.Body = full.Body
+		}
+	}
+
+	reranked, err := rerank.Apply(ctx.Ctx, s.reranker, ctx.Issue, candidates, s.topK)
+	if err != nil {
+		// Keep the raw similarity order rather than failing the pipeline
+		log.Printf("Warning: re-ranking failed: %v", err)
+		return nil
+	}
+
+	for i := range reranked {
+		reranked[i].Issue.Body = originalBodies[candidateKey(reranked[i].Issue)]
+	}
+
+	ctx.SimilarIssues = reranked
+	ctx.Result.SimilarFound = reranked
+
+	return nil
+}
+
+// candidateKey identifies a candidate issue across repositories
+func candidateKey(issue models.Issue) string {
+	return fmt.Sprintf("%s/%s#%d", issue.Org, issue.Repo, issue.Number)
+}
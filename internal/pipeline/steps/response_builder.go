@@ -12,8 +12,10 @@ import (
 	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/internal/pipeline/core"
 	"github.com/Kavirubc/gh-simili/internal/processor"
+	"github.com/Kavirubc/gh-simili/internal/rerank"
 	"github.com/Kavirubc/gh-simili/internal/triage"
 	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
 // ResponseBuilder constructs the unified comment body based on results.
@@ -40,7 +42,7 @@ func (s *ResponseBuilder) buildComment(ctx *core.Context) string {
 	similarIssues := ctx.SimilarIssues
 	issue := ctx.Issue
 
-	if len(similarIssues) == 0 && result.TriageResult == nil && ctx.TransferTarget == "" {
+	if len(similarIssues) == 0 && result.TriageResult == nil && ctx.TransferTarget == "" && ctx.SuggestedTransfer == nil {
 		return ""
 	}
 
@@ -65,11 +67,14 @@ func (s *ResponseBuilder) buildComment(ctx *core.Context) string {
 	if ctx.TransferTarget != "" && !(ctx.Config.Defaults.DelayedActions.Enabled && ctx.Config.Defaults.DelayedActions.OptimisticTransfers) {
 		sections = append(sections, s.formatTransferSection(ctx, ctx.TransferTarget, ctx.Result.PendingAction))
 	}
+	if ctx.SuggestedTransfer != nil {
+		sections = append(sections, s.formatSuggestedTransferSection(ctx.Result.SuggestedTransfer, ctx.SuggestedTransfer.Reason))
+	}
 
 	// Footer
 	footer := "\n---\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>"
 	if ctx.Result.PendingAction != nil {
-		metadata, err := pending.FormatPendingActionMetadata(ctx.Result.PendingAction)
+		metadata, err := pending.FormatPendingActionMetadata(ctx.Result.PendingAction, ctx.Config.Defaults.DelayedActions.SigningSecret)
 		if err == nil {
 			footer = "\n\n" + metadata + footer
 		}
@@ -110,25 +115,50 @@ func (s *ResponseBuilder) appendTriageSections(sections *[]string, triageResult
 				truncateString(triageResult.Duplicate.Original.Title, 50),
 				triageResult.Duplicate.Original.URL)
 		}
+		if triageResult.Duplicate.Rationale != "" {
+			dupLine += fmt.Sprintf("\nWhy: %s", triageResult.Duplicate.Rationale)
+		}
 		*sections = append(*sections, dupLine)
 	}
 }
 
+// FormatRelatedIssues renders similar issues as the summary comment's table
+func FormatRelatedIssues(issue *models.Issue, results []vectordb.SearchResult) string {
+	crossRepo := processor.HasCrossRepoResults(results, issue.Org, issue.Repo)
+	return (&ResponseBuilder{}).formatSimilarIssuesSection(results, crossRepo)
+}
+
 func (s *ResponseBuilder) formatSimilarIssuesSection(results []vectordb.SearchResult, crossRepo bool) string {
 	if len(results) == 0 {
 		return ""
 	}
 
+	// Re-ranked results carry a justification worth showing
+	hasReasons := false
+	for _, r := range results {
+		if r.Reason != "" {
+			hasReasons = true
+			break
+		}
+	}
+
 	var sb strings.Builder
 	sb.WriteString("### 🔍 Related Issues\n\n")
 
+	header := "| Issue |"
+	divider := "|-------|"
 	if crossRepo {
-		sb.WriteString("| Issue | Repository | Similarity | Status |\n")
-		sb.WriteString("|-------|------------|------------|--------|\n")
-	} else {
-		sb.WriteString("| Issue | Similarity | Status |\n")
-		sb.WriteString("|-------|------------|--------|\n")
+		header += " Repository |"
+		divider += "------------|"
 	}
+	header += " Similarity | Status |"
+	divider += "------------|--------|"
+	if hasReasons {
+		header += " Why |"
+		divider += "-----|"
+	}
+	sb.WriteString(header + "\n")
+	sb.WriteString(divider + "\n")
 
 	for _, r := range results {
 		status := "🟢 Open"
@@ -140,18 +170,31 @@ func (s *ResponseBuilder) formatSimilarIssuesSection(results []vectordb.SearchRe
 		link := fmt.Sprintf("[#%d - %s](%s)", r.Issue.Number, title, r.Issue.URL)
 		similarity := fmt.Sprintf("%.0f%%", r.Score*100)
 
+		row := fmt.Sprintf("| %s |", link)
 		if crossRepo {
-			repo := fmt.Sprintf("%s/%s", r.Issue.Org, r.Issue.Repo)
-			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", link, repo, similarity, status))
-		} else {
-			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", link, similarity, status))
+			row += fmt.Sprintf(" %s/%s |", r.Issue.Org, r.Issue.Repo)
 		}
+		row += fmt.Sprintf(" %s | %s |", similarity, status)
+		if hasReasons {
+			row += fmt.Sprintf(" %s |", formatRelation(r))
+		}
+		sb.WriteString(row + "\n")
 	}
 
 	sb.WriteString("\nIf any of these address your problem, please let us know!")
 	return sb.String()
 }
 
+// formatRelation renders the re-ranking verdict for a table cell
+func formatRelation(r vectordb.SearchResult) string {
+	// Pipes and line breaks would end the table row early
+	reason := strings.Join(strings.Fields(strings.ReplaceAll(r.Reason, "|", "/")), " ")
+	if r.Relation == rerank.RelationSameRootCause {
+		return "🎯 " + reason
+	}
+	return reason
+}
+
 func (s *ResponseBuilder) formatTransferSection(ctx *core.Context, target string, action *pending.PendingAction) string {
 	var sb strings.Builder
 	sb.WriteString("### 🔄 Transfer Suggestion\n\n")
@@ -163,7 +206,8 @@ func (s *ResponseBuilder) formatTransferSection(ctx *core.Context, target string
 		sb.WriteString(fmt.Sprintf("**This issue will be transferred in %d hours.**\n\n", delayHours))
 		sb.WriteString("**React to this comment:**\n")
 		sb.WriteString(fmt.Sprintf("- 👍 (%s) to approve and proceed with transfer\n", ctx.Config.Defaults.DelayedActions.ApproveReaction))
-		sb.WriteString(fmt.Sprintf("- 👎 (%s) to cancel this transfer\n\n", ctx.Config.Defaults.DelayedActions.CancelReaction))
+		sb.WriteString(fmt.Sprintf("- 👎 (%s) to cancel this transfer\n", ctx.Config.Defaults.DelayedActions.CancelReaction))
+		sb.WriteString(pending.FormatAuthorization(ctx.Config.Defaults.DelayedActions.Authorization) + "\n")
 		sb.WriteString(fmt.Sprintf("**Deadline**: %s\n\n", deadline))
 		sb.WriteString("If no reaction is provided, the transfer will proceed automatically.")
 	} else {
@@ -173,6 +217,17 @@ func (s *ResponseBuilder) formatTransferSection(ctx *core.Context, target string
 	return sb.String()
 }
 
+func (s *ResponseBuilder) formatSuggestedTransferSection(target, reason string) string {
+	var sb strings.Builder
+	sb.WriteString("### 🔄 Transfer Suggestion\n\n")
+	sb.WriteString(fmt.Sprintf("This issue may belong in **%s**.", target))
+	if reason != "" {
+		sb.WriteString(fmt.Sprintf(" %s.", strings.TrimSuffix(reason, ".")))
+	}
+	sb.WriteString("\n\nA maintainer can transfer it if that is the right place.")
+	return sb.String()
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
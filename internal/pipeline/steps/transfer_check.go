@@ -20,15 +20,18 @@ import (
 
 // TransferCheck evaluates if an issue matches any transfer rules or AI intent routing.
 type TransferCheck struct {
-	llm llm.Provider
-	gh  *github.Client
+	llm    llm.Provider
+	gh     *github.Client
+	scorer triage.RepoScorer
 }
 
-// NewTransferCheck creates a new transfer check step
-func NewTransferCheck(llmProvider llm.Provider, gh *github.Client) *TransferCheck {
+// NewTransferCheck creates a new transfer check step. scorer is used by the
+// semantic routing strategy and may be nil.
+func NewTransferCheck(llmProvider llm.Provider, gh *github.Client, scorer triage.RepoScorer) *TransferCheck {
 	return &TransferCheck{
-		llm: llmProvider,
-		gh:  gh,
+		llm:    llmProvider,
+		gh:     gh,
+		scorer: scorer,
 	}
 }
 
@@ -42,9 +45,22 @@ func (s *TransferCheck) Run(ctx *core.Context) error {
 		return nil
 	}
 
+	var (
+		comments []github.Comment
+		mapping  *transfer.Mapping
+	)
+	if s.gh != nil {
+		var err error
+		comments, err = s.gh.ListComments(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number)
+		if err != nil {
+			log.Printf("Warning: failed to check comments for earlier transfers: %v", err)
+		}
+		mapping = transfer.FindMapping(ctx.Ctx, s.gh, ctx.Config, comments)
+	}
+
 	// 1. Check for Revert Loop Prevention
 	// If the issue was recently reverted, we skip automatic transfer
-	if s.isReverted(ctx) {
+	if s.isReverted(ctx, mapping, comments) {
 		log.Printf("Issue #%d was recently reverted, skipping automatic transfer to prevent loops", ctx.Issue.Number)
 		return nil
 	}
@@ -59,33 +75,21 @@ func (s *TransferCheck) Run(ctx *core.Context) error {
 	}
 
 	// 3. Fallback to AI Intent Routing (Slow but Accurate)
-	if target == "" && ctx.Config.Triage.Router.Enabled && s.llm != nil {
-		router := triage.NewRouter(s.llm, ctx.Config.Repositories)
+	router := triage.NewIssueRouter(ctx.Config, s.llm, s.scorer)
+	if target == "" && router != nil {
 		result, err := router.Route(ctx.Ctx, ctx.Issue)
 		if err != nil {
-			log.Printf("Warning: AI routing failed: %v", err)
-		} else if result != nil && result.Confidence >= 0.8 {
-			// Ensure we don't route to the same repo
-			currentRepo := strings.ToLower(ctx.Issue.Org + "/" + ctx.Issue.Repo)
-			targetRepo := strings.ToLower(result.TargetRepo)
-
-			if targetRepo != currentRepo {
-				// Validate that target repo actually exists in config
-				found := false
-				for _, r := range ctx.Config.Repositories {
-					if strings.ToLower(r.Org+"/"+r.Repo) == targetRepo {
-						found = true
-						break
-					}
-				}
-
-				if found {
-					log.Printf("AI Router suggested transfer: %s -> %s (Reason: %s)", currentRepo, result.TargetRepo, result.Reason)
-					target = result.TargetRepo
-				} else {
-					log.Printf("Warning: AI Router suggested unknown target repository: %s", result.TargetRepo)
-				}
+			log.Printf("Warning: routing failed: %v", err)
+		} else if suggested := triage.SuggestedTarget(ctx.Config, ctx.Issue, result); suggested != "" {
+			log.Printf("Router suggested transfer: %s -> %s (Reason: %s)", ctx.Issue.FullRepo(), suggested, result.Reason)
+			if ctx.Config.Triage.Router.SuggestOnly {
+				// Only mention the suggestion in the summary comment
+				ctx.SuggestedTransfer = result
+				ctx.Result.SuggestedTransfer = suggested
+				return nil
 			}
+			target = suggested
+			ctx.TransferRouting = result
 		}
 	}
 
@@ -93,6 +97,12 @@ func (s *TransferCheck) Run(ctx *core.Context) error {
 		return nil
 	}
 
+	// Never send an issue back to a repository it has already been in
+	if mapping.Visited(target) {
+		log.Printf("Issue #%d was already in %s, skipping transfer to prevent a loop", ctx.Issue.Number, target)
+		return nil
+	}
+
 	// Match found
 	log.Printf("Transfer target identified: %s -> %s", ctx.Issue.Repo, target)
 	ctx.TransferTarget = target
@@ -116,8 +126,14 @@ func (s *TransferCheck) Run(ctx *core.Context) error {
 	return nil
 }
 
-// isReverted checks if the issue was recently moved back via revert
-func (s *TransferCheck) isReverted(ctx *core.Context) bool {
+// isReverted checks if the issue was recently moved back via revert. The
+// transfer mapping is authoritative; issues moved before mappings were
+// recorded fall back to looking for the revert notice.
+func (s *TransferCheck) isReverted(ctx *core.Context, mapping *transfer.Mapping, comments []github.Comment) bool {
+	if mapping.IsAt(ctx.Issue.Org, ctx.Issue.Repo) {
+		return mapping.Last().Revert
+	}
+
 	revertMarker := "↩️ Reverting transfer"
 
 	// 1. Check issue body
@@ -125,22 +141,14 @@ func (s *TransferCheck) isReverted(ctx *core.Context) bool {
 		return true
 	}
 
-	// 2. Check comment history (most reliable)
-	// We only check if gh client is available
-	if s.gh != nil {
-		comments, err := s.gh.ListComments(ctx.Ctx, ctx.Issue.Org, ctx.Issue.Repo, ctx.Issue.Number)
-		if err != nil {
-			log.Printf("Warning: failed to check comments for revert marker: %v", err)
-		} else {
-			for i := len(comments) - 1; i >= 0; i-- {
-				if strings.Contains(comments[i].Body, revertMarker) {
-					return true
-				}
-				// Only look at recent comments to allow future transfers
-				if i < len(comments)-5 {
-					break
-				}
-			}
+	// 2. Check comment history
+	for i := len(comments) - 1; i >= 0; i-- {
+		if strings.Contains(comments[i].Body, revertMarker) {
+			return true
+		}
+		// Only look at recent comments to allow future transfers
+		if i < len(comments)-5 {
+			break
 		}
 	}
 
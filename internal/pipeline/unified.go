@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/Kavirubc/gh-simili/internal/command"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/embedding"
 	"github.com/Kavirubc/gh-simili/internal/github"
@@ -166,6 +167,34 @@ func (up *UnifiedProcessor) Close() error {
 	return nil
 }
 
+// reindexForComment re-embeds an issue after one of its comments was
+// created, edited or deleted, but only when that comment is among the ones
+// embedded, so bot chatter and low-ranked comments cost no embedding calls
+func (up *UnifiedProcessor) reindexForComment(ctx context.Context, issue *models.Issue, comment github.Comment) {
+	if !processor.Embeddable(comment) {
+		return
+	}
+
+	comments, err := up.gh.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		log.Printf("Warning: failed to list comments of issue #%d for re-indexing: %v", issue.Number, err)
+		return
+	}
+	if !processor.Selected(comments, comment, &up.cfg.Embedding.Comments) {
+		log.Printf("Comment %d on issue #%d is not among the embedded comments, skipping re-index", comment.ID, issue.Number)
+		return
+	}
+
+	full, err := up.gh.GetIssue(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		log.Printf("Warning: failed to fetch issue #%d for re-indexing: %v", issue.Number, err)
+		return
+	}
+	if err := up.indexer.IndexSingleIssue(ctx, full); err != nil {
+		log.Printf("Warning: failed to re-index issue #%d after comment: %v", issue.Number, err)
+	}
+}
+
 // ProcessEvent processes a GitHub Action event through the unified pipeline
 func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string) (*core.UnifiedResult, error) {
 	event, err := github.ParseEventFile(eventPath)
@@ -179,7 +208,18 @@ func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string)
 		if issue == nil {
 			return nil, fmt.Errorf("failed to parse issue from comment event")
 		}
-		return up.ProcessCommentEvent(ctx, issue)
+
+		// Keep comment embeddings fresh when comments are indexed
+		if up.cfg.Embedding.Comments.Enabled {
+			up.reindexForComment(ctx, issue, event.Comment.ToComment())
+		}
+
+		// Only new comments can carry commands
+		comment := event.Comment
+		if event.Action != "created" {
+			comment = nil
+		}
+		return up.ProcessCommentEvent(ctx, issue, comment)
 	}
 
 	if !event.IsIssueEvent() {
@@ -227,12 +267,18 @@ func (up *UnifiedProcessor) ProcessEvent(ctx context.Context, eventPath string)
 
 // ProcessIssue processes a single issue through the configured pipeline
 func (up *UnifiedProcessor) ProcessIssue(ctx context.Context, issue *models.Issue) (*core.UnifiedResult, error) {
+	return up.processIssue(ctx, issue, false)
+}
+
+// processIssue runs the pipeline; force skips the comment cooldown
+func (up *UnifiedProcessor) processIssue(ctx context.Context, issue *models.Issue, force bool) (*core.UnifiedResult, error) {
 	// Initialize Pipeline Context
 	pCtx := &core.Context{
 		Ctx:    ctx,
 		Issue:  issue,
 		Config: up.cfg,
 		Result: &core.UnifiedResult{IssueNumber: issue.Number},
+		Force:  force,
 	}
 
 	// Execute Steps
@@ -249,11 +295,30 @@ func (up *UnifiedProcessor) ProcessIssue(ctx context.Context, issue *models.Issu
 	return pCtx.Result, nil
 }
 
-// ProcessCommentEvent keeps the legacy logic for now, as it handles specific interactions
+// ProcessCommentEvent keeps the legacy logic for now, as it handles specific interactions.
+// Slash commands in a new comment are run first; comment is nil for edits and deletions.
 // TODO: Refactor this into a separate "InteractionPipeline" in future.
-func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *models.Issue) (*core.UnifiedResult, error) {
+func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *models.Issue, comment *github.EventComment) (*core.UnifiedResult, error) {
+	if comment != nil && up.cfg.Commands.Enabled {
+		if commands := command.Parse(comment.Body, up.cfg.Commands.Prefix); len(commands) > 0 {
+			return up.processCommands(ctx, issue, comment, commands)
+		}
+	}
+
 	result := &core.UnifiedResult{IssueNumber: issue.Number}
 
+	// An author reply reopens an issue closed for lack of information
+	if comment != nil && comment.User != nil {
+		reopened, err := up.triageExecutor().ReopenNeedsInfo(ctx, issue, comment.User.Login)
+		if err != nil {
+			log.Printf("Error reopening needs-info issue: %v", err)
+		}
+		if reopened {
+			result.ActionsExecuted = 1
+			return result, nil
+		}
+	}
+
 	// Create pending manager
 	pendingMgr := pending.NewManager(up.gh, up.cfg)
 
@@ -275,7 +340,7 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 
 	if revertAction != nil {
 		log.Printf("Found revert action for issue #%d, executing...", issue.Number)
-		executor := transfer.NewExecutor(up.transferClient, up.gh, up.vdb, up.cfg, up.dryRun)
+		executor := up.TransferExecutor()
 		if err := revertMgr.Revert(ctx, issue, revertAction, executor); err != nil {
 			return nil, fmt.Errorf("failed to execute revert: %w", err)
 		}
@@ -295,7 +360,7 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 
 	switch action.Type {
 	case pending.ActionTypeTransfer:
-		executor := transfer.NewExecutor(up.transferClient, up.gh, up.vdb, up.cfg, up.dryRun)
+		executor := up.TransferExecutor()
 		if err := executor.ProcessPendingTransfer(ctx, action); err != nil {
 			return nil, fmt.Errorf("failed to process pending transfer: %w", err)
 		}
@@ -308,11 +373,44 @@ func (up *UnifiedProcessor) ProcessCommentEvent(ctx context.Context, issue *mode
 			return nil, fmt.Errorf("failed to process pending close: %w", err)
 		}
 		result.ActionsExecuted = 1
+
+	case pending.ActionTypeTriage, pending.ActionTypeNeedsInfo:
+		if err := up.triageExecutor().ProcessPendingAction(ctx, action); err != nil {
+			return nil, fmt.Errorf("failed to process pending %s action: %w", action.Type, err)
+		}
+		result.ActionsExecuted = 1
 	}
 
 	return result, nil
 }
 
+// triageExecutor creates a triage action executor with delayed action support
+func (up *UnifiedProcessor) triageExecutor() *triage.Executor {
+	dChecker := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&up.cfg.Triage.Duplicate, up.gh, up.cfg, up.dryRun)
+	return triage.NewExecutorWithDelayedActions(up.gh, up.cfg, dChecker, up.dryRun)
+}
+
+// TransferExecutor creates a transfer executor that indexes copied issues
+// and classifies issues on arrival. Every transfer, whether from an event or
+// a scheduled run, should go through it.
+func (up *UnifiedProcessor) TransferExecutor() *transfer.Executor {
+	return newTransferExecutor(up.cfg, up.transferClient, up.gh, up.vdb, up.indexer, up.triageAgent, up.dryRun)
+}
+
+// newTransferExecutor creates a transfer executor wired to the indexer and,
+// when triage is on, the triage agent
+func newTransferExecutor(cfg *config.Config, transferClient, gh *github.Client, vdb *vectordb.Client, indexer *processor.Indexer, agent *triage.Agent, dryRun bool) *transfer.Executor {
+	executor := transfer.NewExecutor(transferClient, gh, vdb, cfg, dryRun)
+	if indexer != nil {
+		executor.SetIndexer(indexer)
+	}
+	// Checked here so a nil agent does not become a non-nil interface
+	if agent != nil {
+		executor.SetClassifier(agent)
+	}
+	return executor
+}
+
 // PrintUnifiedResult outputs the processing result to stdout
 // Helper method for CLI visualization
 func PrintUnifiedResult(result *core.UnifiedResult) {
@@ -336,6 +434,10 @@ func PrintUnifiedResult(result *core.UnifiedResult) {
 		fmt.Printf("Transfer to %s: %s\n", result.TransferTarget, status)
 	}
 
+	if result.SuggestedTransfer != "" {
+		fmt.Printf("Suggested transfer to %s (suggest only)\n", result.SuggestedTransfer)
+	}
+
 	if result.TriageResult != nil {
 		if len(result.TriageResult.Labels) > 0 {
 			fmt.Println("Labels:")
@@ -353,6 +455,13 @@ func PrintUnifiedResult(result *core.UnifiedResult) {
 		}
 	}
 
+	if len(result.Commands) > 0 {
+		fmt.Println("Commands:")
+		for _, c := range result.Commands {
+			fmt.Printf("  - %s\n", c)
+		}
+	}
+
 	if result.CommentPosted {
 		fmt.Println("Comment: posted")
 	}
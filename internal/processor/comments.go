@@ -0,0 +1,75 @@
+package processor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// SelectComments picks the comments worth embedding for an issue.
+// Bot comments and simili's own comments are skipped; the rest are ranked
+// by reactions, or maintainers first when rank_by is "maintainer".
+func SelectComments(comments []github.Comment, cfg *config.CommentsConfig) []models.Comment {
+	var candidates []models.Comment
+	for _, c := range comments {
+		if !Embeddable(c) {
+			continue
+		}
+		candidates = append(candidates, models.Comment{
+			ID:         c.ID,
+			Author:     c.User.Login,
+			Body:       c.Body,
+			Reactions:  c.Reactions.TotalCount,
+			Maintainer: c.IsMaintainer(),
+		})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if cfg.RankBy == "maintainer" && candidates[i].Maintainer != candidates[j].Maintainer {
+			return candidates[i].Maintainer
+		}
+		return candidates[i].Reactions > candidates[j].Reactions
+	})
+
+	if cfg.MaxComments > 0 && len(candidates) > cfg.MaxComments {
+		candidates = candidates[:cfg.MaxComments]
+	}
+
+	return candidates
+}
+
+// Embeddable reports whether a comment can be embedded at all: bot comments,
+// simili's own and empty ones never are
+func Embeddable(c github.Comment) bool {
+	return c.User.Type != "Bot" && !strings.Contains(c.Body, "Powered by [Simili") && strings.TrimSpace(c.Body) != ""
+}
+
+// Selected reports whether comment ranks among the comments SelectComments
+// keeps. A deleted comment missing from comments is ranked as if it were
+// still there, since it may have been embedded.
+func Selected(comments []github.Comment, comment github.Comment, cfg *config.CommentsConfig) bool {
+	if !Embeddable(comment) {
+		return false
+	}
+
+	found := false
+	for _, c := range comments {
+		if c.ID == comment.ID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		comments = append(comments[:len(comments):len(comments)], comment)
+	}
+
+	for _, c := range SelectComments(comments, cfg) {
+		if c.ID == comment.ID {
+			return true
+		}
+	}
+	return false
+}
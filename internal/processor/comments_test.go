@@ -0,0 +1,85 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+)
+
+func comment(id int, login, association string, reactions int, body string) github.Comment {
+	return github.Comment{
+		ID:                id,
+		Body:              body,
+		User:              github.User{Login: login, Type: "User"},
+		AuthorAssociation: association,
+		Reactions:         github.ReactionsSummary{TotalCount: reactions},
+	}
+}
+
+func TestSelectComments(t *testing.T) {
+	bot := comment(4, "dependabot[bot]", "NONE", 9, "Bumps x")
+	bot.User.Type = "Bot"
+
+	comments := []github.Comment{
+		comment(1, "alice", "NONE", 1, "Same here"),
+		comment(2, "bob", "MEMBER", 0, "Fixed in main"),
+		comment(3, "carol", "NONE", 5, "Workaround: restart"),
+		bot,
+		comment(5, "simili", "NONE", 7, "Similar issues\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>"),
+		comment(6, "dave", "NONE", 3, "   "),
+	}
+
+	tests := []struct {
+		name string
+		cfg  config.CommentsConfig
+		want []int
+	}{
+		{"by reactions", config.CommentsConfig{RankBy: "reactions"}, []int{3, 1, 2}},
+		{"maintainers first", config.CommentsConfig{RankBy: "maintainer"}, []int{2, 3, 1}},
+		{"limited", config.CommentsConfig{RankBy: "reactions", MaxComments: 2}, []int{3, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SelectComments(comments, &tt.cfg)
+			if len(got) != len(tt.want) {
+				t.Fatalf("SelectComments() returned %d comments, want %d", len(got), len(tt.want))
+			}
+			for i, c := range got {
+				if c.ID != tt.want[i] {
+					t.Errorf("SelectComments()[%d].ID = %d, want %d", i, c.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSelected(t *testing.T) {
+	cfg := &config.CommentsConfig{RankBy: "reactions", MaxComments: 2}
+	comments := []github.Comment{
+		comment(1, "alice", "NONE", 4, "Same here"),
+		comment(2, "bob", "NONE", 2, "Also seeing it"),
+		comment(3, "carol", "NONE", 0, "+1"),
+	}
+	simili := comment(4, "simili", "NONE", 9, "<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>")
+
+	tests := []struct {
+		name    string
+		comment github.Comment
+		want    bool
+	}{
+		{"in the top comments", comments[1], true},
+		{"ranked out", comments[2], false},
+		{"deleted but ranked in", comment(9, "dave", "NONE", 5, "Root cause found"), true},
+		{"simili comment", simili, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Selected(comments, tt.comment, cfg); got != tt.want {
+				t.Errorf("Selected() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,11 +14,12 @@ import (
 
 // Indexer handles bulk indexing of issues
 type Indexer struct {
-	cfg      *config.Config
-	gh       *github.Client
-	embedder *embedding.FallbackProvider
-	vdb      *vectordb.Client
-	dryRun   bool
+	cfg        *config.Config
+	gh         *github.Client
+	embedder   *embedding.FallbackProvider
+	normalizer *embedding.Normalizer
+	vdb        *vectordb.Client
+	dryRun     bool
 }
 
 // NewIndexer creates a new bulk indexer
@@ -39,11 +40,12 @@ func NewIndexer(cfg *config.Config, dryRun bool) (*Indexer, error) {
 	}
 
 	return &Indexer{
-		cfg:      cfg,
-		gh:       gh,
-		embedder: embedder,
-		vdb:      vdb,
-		dryRun:   dryRun,
+		cfg:        cfg,
+		gh:         gh,
+		embedder:   embedder,
+		normalizer: embedding.NewNormalizer(&cfg.Embedding.Normalize),
+		vdb:        vdb,
+		dryRun:     dryRun,
 	}, nil
 }
 
@@ -106,8 +108,10 @@ func (idx *Indexer) IndexRepo(ctx context.Context, fullRepo string, batchSize in
 func (idx *Indexer) indexBatch(ctx context.Context, collection string, issues []*models.Issue) error {
 	// Prepare texts for embedding
 	texts := make([]string, len(issues))
+	comments := make([][]models.Comment, len(issues))
 	for i, issue := range issues {
-		texts[i] = embedding.PrepareIssueText(issue.Title, issue.Body)
+		comments[i] = idx.fetchComments(ctx, issue)
+		texts[i] = idx.issueText(issue, comments[i])
 	}
 
 	// Generate embeddings
@@ -120,11 +124,21 @@ func (idx *Indexer) indexBatch(ctx context.Context, collection string, issues []
 		return nil
 	}
 
+	idx.keepClusters(ctx, collection, issues)
+
 	// Upsert to Qdrant
 	if err := idx.vdb.UpsertBatch(ctx, collection, issues, vectors); err != nil {
 		return fmt.Errorf("failed to upsert batch: %w", err)
 	}
 
+	if idx.separateComments() {
+		for i, issue := range issues {
+			if err := idx.indexCommentPoints(ctx, collection, issue, comments[i]); err != nil {
+				fmt.Printf("Warning: failed to index comments for #%d: %v\n", issue.Number, err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -132,7 +146,8 @@ func (idx *Indexer) indexBatch(ctx context.Context, collection string, issues []
 func (idx *Indexer) IndexSingleIssue(ctx context.Context, issue *models.Issue) error {
 	collection := vectordb.CollectionName(issue.Org)
 
-	text := embedding.PrepareIssueText(issue.Title, issue.Body)
+	comments := idx.fetchComments(ctx, issue)
+	text := idx.issueText(issue, comments)
 	vector, err := idx.embedder.Embed(ctx, text)
 	if err != nil {
 		return fmt.Errorf("failed to generate embedding: %w", err)
@@ -142,20 +157,103 @@ func (idx *Indexer) IndexSingleIssue(ctx context.Context, issue *models.Issue) e
 		return nil
 	}
 
+	idx.keepClusters(ctx, collection, []*models.Issue{issue})
+
 	if err := idx.vdb.Upsert(ctx, collection, issue, vector); err != nil {
 		return fmt.Errorf("failed to upsert issue: %w", err)
 	}
 
+	if idx.separateComments() {
+		if err := idx.indexCommentPoints(ctx, collection, issue, comments); err != nil {
+			return fmt.Errorf("failed to index comments: %w", err)
+		}
+	}
+
 	return nil
 }
 
-// DeleteIssue removes an issue from the index
+// fetchComments returns the comments selected for embedding, or nil when
+// comment indexing is disabled or the comments cannot be fetched
+func (idx *Indexer) fetchComments(ctx context.Context, issue *models.Issue) []models.Comment {
+	if !idx.cfg.Embedding.Comments.Enabled {
+		return nil
+	}
+
+	comments, err := idx.gh.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		fmt.Printf("Warning: failed to fetch comments for #%d: %v\n", issue.Number, err)
+		return nil
+	}
+
+	return SelectComments(comments, &idx.cfg.Embedding.Comments)
+}
+
+// issueText builds the embedding text, folding comments in when configured
+func (idx *Indexer) issueText(issue *models.Issue, comments []models.Comment) string {
+	if idx.separateComments() || len(comments) == 0 {
+		return idx.normalizer.Prepare(issue.Title, issue.Body)
+	}
+
+	bodies := make([]string, len(comments))
+	for i, c := range comments {
+		bodies[i] = c.Body
+	}
+	return idx.normalizer.PrepareWithComments(issue.Title, issue.Body, bodies)
+}
+
+// separateComments reports whether comments are stored as their own points
+func (idx *Indexer) separateComments() bool {
+	return idx.cfg.Embedding.Comments.Enabled && idx.cfg.Embedding.Comments.Mode == "separate"
+}
+
+// indexCommentPoints replaces the comment points linked to an issue
+func (idx *Indexer) indexCommentPoints(ctx context.Context, collection string, issue *models.Issue, comments []models.Comment) error {
+	if err := idx.vdb.DeleteIssueComments(ctx, collection, issue.Org, issue.Repo, issue.Number); err != nil {
+		return err
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+
+	texts := make([]string, len(comments))
+	for i, c := range comments {
+		texts[i] = idx.normalizer.PrepareWithComments(issue.Title, "", []string{c.Body})
+	}
+
+	vectors, err := idx.embedder.EmbedBatch(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to generate comment embeddings: %w", err)
+	}
+
+	return idx.vdb.UpsertComments(ctx, collection, issue, comments, vectors)
+}
+
+// keepClusters copies stored cluster IDs onto issues about to be re-upserted,
+// since an upsert replaces the whole payload
+func (idx *Indexer) keepClusters(ctx context.Context, collection string, issues []*models.Issue) {
+	if !idx.cfg.Clustering.Enabled {
+		return
+	}
+
+	clusters, err := idx.vdb.ClusterIDs(ctx, collection, issues)
+	if err != nil {
+		fmt.Printf("Warning: failed to load cluster ids: %v\n", err)
+		return
+	}
+
+	for _, issue := range issues {
+		if issue.ClusterID == "" {
+			issue.ClusterID = clusters[issue.UUID()]
+		}
+	}
+}
+
+// DeleteIssue removes an issue and its comment points from the index
 func (idx *Indexer) DeleteIssue(ctx context.Context, org, repo string, number int) error {
 	if idx.dryRun {
 		return nil
 	}
 
 	collection := vectordb.CollectionName(org)
-	id := models.IssueUUID(org, repo, number)
-	return idx.vdb.Delete(ctx, collection, id)
+	return idx.vdb.DeleteIssuePoints(ctx, collection, org, repo, number)
 }
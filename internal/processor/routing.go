@@ -0,0 +1,135 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// RepoScores scores how closely an issue resembles the indexed issues of each
+// repository, keyed by lowercased "org/repo". The "centroid" method uses the
+// cosine similarity to each repository's mean issue vector; "knn" uses each
+// repository's similarity-weighted share of the issue's nearest neighbors.
+func (sf *SimilarityFinder) RepoScores(ctx context.Context, issue *models.Issue, repos []string, method string, neighbors int) (map[string]float64, error) {
+	text := sf.normalizer.Prepare(issue.Title, issue.Body)
+	vector, err := sf.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+
+	if method == "knn" {
+		return sf.neighborScores(ctx, issue, vector, repos, neighbors)
+	}
+	return sf.centroidScores(ctx, issue, vector, repos)
+}
+
+// centroidScores compares the issue vector with each repository's centroid.
+// An already indexed issue is left out of its own repository's centroid so
+// it does not pull the score towards where it currently is.
+func (sf *SimilarityFinder) centroidScores(ctx context.Context, issue *models.Issue, vector []float32, repos []string) (map[string]float64, error) {
+	own := strings.ToLower(issue.FullRepo())
+	scores := make(map[string]float64, len(repos))
+	for _, fullRepo := range repos {
+		key := strings.ToLower(fullRepo)
+		centroid, ok := sf.centroids[key]
+		if !ok {
+			org, repo, err := github.ParseRepo(fullRepo)
+			if err != nil {
+				return nil, err
+			}
+			centroid, err = sf.vdb.RepoCentroid(ctx, vectordb.CollectionName(org), org, repo)
+			if err != nil {
+				log.Printf("Warning: failed to compute centroid for %s: %v", fullRepo, err)
+				continue
+			}
+			sf.centroids[key] = centroid
+		}
+		if centroid == nil {
+			continue // nothing indexed yet
+		}
+
+		exclude := 0
+		if key == own {
+			exclude = issue.Number
+		}
+		mean := centroid.Vector(exclude)
+		if mean == nil {
+			continue // only the issue itself is indexed
+		}
+		scores[key] = vectordb.Cosine(vector, mean)
+	}
+	return scores, nil
+}
+
+// neighborScores lets the nearest indexed issues of the candidate repositories vote
+func (sf *SimilarityFinder) neighborScores(ctx context.Context, issue *models.Issue, vector []float32, repos []string, neighbors int) (map[string]float64, error) {
+	// Collections are per organization
+	byOrg := make(map[string][]*qdrant.Condition)
+	for _, fullRepo := range repos {
+		org, repo, err := github.ParseRepo(fullRepo)
+		if err != nil {
+			return nil, err
+		}
+		byOrg[org] = append(byOrg[org], qdrant.NewFilterAsCondition(&qdrant.Filter{
+			Must: []*qdrant.Condition{
+				qdrant.NewMatchKeyword("org", org),
+				qdrant.NewMatchKeyword("repo", repo),
+			},
+		}))
+	}
+
+	var results []vectordb.SearchResult
+	for org, repoConditions := range byOrg {
+		filter := &qdrant.Filter{
+			Should: repoConditions,
+			MustNot: []*qdrant.Condition{
+				qdrant.NewMatchKeyword("kind", vectordb.KindComment),
+				qdrant.NewFilterAsCondition(&qdrant.Filter{
+					Must: []*qdrant.Condition{
+						qdrant.NewMatchKeyword("org", issue.Org),
+						qdrant.NewMatchKeyword("repo", issue.Repo),
+						qdrant.NewMatchInt("number", int64(issue.Number)),
+					},
+				}),
+			},
+		}
+		found, err := sf.vdb.SearchFiltered(ctx, vectordb.CollectionName(org), vector, neighbors, 0, 0, filter)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, found...)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > neighbors {
+		results = results[:neighbors]
+	}
+
+	return voteShares(results), nil
+}
+
+// voteShares returns each repository's share of the total similarity
+func voteShares(results []vectordb.SearchResult) map[string]float64 {
+	scores := make(map[string]float64)
+	var total float64
+	for _, r := range results {
+		if r.Score <= 0 {
+			continue
+		}
+		scores[strings.ToLower(r.Issue.FullRepo())] += r.Score
+		total += r.Score
+	}
+	for repo := range scores {
+		scores[repo] /= total
+	}
+	return scores
+}
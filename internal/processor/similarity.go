@@ -14,23 +14,32 @@ import (
 
 // SimilarityFinder searches for similar issues
 type SimilarityFinder struct {
-	cfg      *config.Config
-	embedder *embedding.FallbackProvider
-	vdb      *vectordb.Client
+	cfg        *config.Config
+	embedder   *embedding.FallbackProvider
+	normalizer *embedding.Normalizer
+	vdb        *vectordb.Client
+	centroids  map[string]*vectordb.Centroid // repository centroids by lowercased org/repo
 }
 
 // NewSimilarityFinder creates a new similarity finder
 func NewSimilarityFinder(cfg *config.Config, embedder *embedding.FallbackProvider, vdb *vectordb.Client) *SimilarityFinder {
 	return &SimilarityFinder{
-		cfg:      cfg,
-		embedder: embedder,
-		vdb:      vdb,
+		cfg:        cfg,
+		embedder:   embedder,
+		normalizer: embedding.NewNormalizer(&cfg.Embedding.Normalize),
+		vdb:        vdb,
+		centroids:  make(map[string]*vectordb.Centroid),
 	}
 }
 
+// VectorDB returns the vector database client used for searches
+func (sf *SimilarityFinder) VectorDB() *vectordb.Client {
+	return sf.vdb
+}
+
 // FindSimilar finds similar issues for a given issue
 func (sf *SimilarityFinder) FindSimilar(ctx context.Context, issue *models.Issue, excludeSelf bool) ([]vectordb.SearchResult, error) {
-	text := embedding.PrepareIssueText(issue.Title, issue.Body)
+	text := sf.normalizer.Prepare(issue.Title, issue.Body)
 	vector, err := sf.embedder.Embed(ctx, text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
@@ -106,6 +115,31 @@ func (sf *SimilarityFinder) FindSimilarByText(ctx context.Context, text string,
 	return sf.vdb.Search(ctx, collection, vector, limit, threshold, closedWeight)
 }
 
+// FindLabeled finds the most similar labeled issues in the same repository,
+// used as classification examples. No similarity threshold is applied.
+func (sf *SimilarityFinder) FindLabeled(ctx context.Context, issue *models.Issue, limit int) ([]vectordb.SearchResult, error) {
+	text := sf.normalizer.Prepare(issue.Title, issue.Body)
+	vector, err := sf.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+
+	filter := &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", issue.Org),
+			qdrant.NewMatchKeyword("repo", issue.Repo),
+		},
+		MustNot: []*qdrant.Condition{
+			qdrant.NewIsEmpty("labels"),
+			qdrant.NewMatchKeyword("kind", vectordb.KindComment),
+			qdrant.NewMatchInt("number", int64(issue.Number)),
+		},
+	}
+
+	collection := vectordb.CollectionName(issue.Org)
+	return sf.vdb.SearchFiltered(ctx, collection, vector, limit, 0, 0, filter)
+}
+
 // FormatSimilarityComment creates the similarity comment for posting
 func FormatSimilarityComment(results []vectordb.SearchResult, crossRepo bool) string {
 	if len(results) == 0 {
@@ -72,7 +72,7 @@ func (s *Syncer) SyncRepo(ctx context.Context, fullRepo string, sinceDuration st
 	}
 
 	// Parse duration
-	since, err := parseSinceDuration(sinceDuration)
+	since, err := ParseSinceDuration(sinceDuration)
 	if err != nil {
 		return nil, fmt.Errorf("invalid since duration: %w", err)
 	}
@@ -111,8 +111,8 @@ func (s *Syncer) SyncRepo(ctx context.Context, fullRepo string, sinceDuration st
 	return stats, nil
 }
 
-// parseSinceDuration parses duration strings like "24h", "7d"
-func parseSinceDuration(s string) (time.Time, error) {
+// ParseSinceDuration parses duration strings like "24h", "7d" into the time that long ago
+func ParseSinceDuration(s string) (time.Time, error) {
 	// Handle day suffix
 	if len(s) > 1 && s[len(s)-1] == 'd' {
 		days := s[:len(s)-1]
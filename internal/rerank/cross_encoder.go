@@ -0,0 +1,117 @@
+package rerank
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// CrossEncoderReranker scores candidates with a cross-encoder served over HTTP.
+// The endpoint follows the text-embeddings-inference /rerank API:
+// POST {"query": "...", "texts": [...]} -> [{"index": 0, "score": 0.93}, ...]
+type CrossEncoderReranker struct {
+	endpoint string
+	apiKey   string
+	minScore float64
+	client   *http.Client
+}
+
+// rerankRequest is the body sent to the cross-encoder endpoint
+type rerankRequest struct {
+	Query string   `json:"query"`
+	Texts []string `json:"texts"`
+}
+
+// rerankScore is a single entry in the cross-encoder response
+type rerankScore struct {
+	Index int     `json:"index"`
+	Score float64 `json:"score"`
+}
+
+// NewCrossEncoderReranker creates a new cross-encoder reranker
+func NewCrossEncoderReranker(endpoint, apiKey string, minScore float64) *CrossEncoderReranker {
+	return &CrossEncoderReranker{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		minScore: minScore,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Rerank orders candidates by cross-encoder score and drops those below minScore
+func (r *CrossEncoderReranker) Rerank(ctx context.Context, issue *models.Issue, candidates []vectordb.SearchResult) ([]vectordb.SearchResult, error) {
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	req := rerankRequest{
+		Query: truncateText(issue.Title+"\n\n"+issue.Body, 2000),
+		Texts: make([]string, len(candidates)),
+	}
+	for i, c := range candidates {
+		req.Texts[i] = truncateText(c.Issue.Title+"\n\n"+c.Issue.Body, 2000)
+	}
+
+	scores, err := r.score(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+
+	results := make([]vectordb.SearchResult, 0, len(candidates))
+	for _, s := range scores {
+		if s.Index < 0 || s.Index >= len(candidates) || s.Score < r.minScore {
+			continue
+		}
+		c := candidates[s.Index]
+		c.Relation = RelationRelated
+		c.Reason = fmt.Sprintf("cross-encoder relevance %.2f", s.Score)
+		results = append(results, c)
+	}
+
+	return results, nil
+}
+
+// score calls the cross-encoder endpoint
+func (r *CrossEncoderReranker) score(ctx context.Context, body rerankRequest) ([]rerankScore, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rerank request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if r.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+r.apiKey)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rerank request failed with status %d", resp.StatusCode)
+	}
+
+	var scores []rerankScore
+	if err := json.NewDecoder(resp.Body).Decode(&scores); err != nil {
+		return nil, fmt.Errorf("failed to decode rerank response: %w", err)
+	}
+
+	return scores, nil
+}
@@ -0,0 +1,128 @@
+package rerank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// LLMReranker asks an LLM whether each candidate shares the issue's root cause
+type LLMReranker struct {
+	llm llm.Provider
+}
+
+// judgement is a single verdict returned by the LLM
+type judgement struct {
+	ID       int    `json:"id"`
+	Relation string `json:"relation"`
+	Reason   string `json:"reason"`
+}
+
+// NewLLMReranker creates a new LLM-based reranker
+func NewLLMReranker(provider llm.Provider) *LLMReranker {
+	return &LLMReranker{llm: provider}
+}
+
+// Rerank judges every candidate in a single prompt
+func (r *LLMReranker) Rerank(ctx context.Context, issue *models.Issue, candidates []vectordb.SearchResult) ([]vectordb.SearchResult, error) {
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	system := `You compare a new GitHub issue against candidate issues found by semantic search.
+For each candidate decide whether it has the "same_root_cause", is "related" (same area but a different problem), or is "unrelated".
+Respond with a JSON array of objects with "id", "relation" and "reason" fields. Keep each reason under 20 words.`
+
+	var sb strings.Builder
+	for i, c := range candidates {
+		sb.WriteString(fmt.Sprintf("[%d] %s/%s#%d (%s): %s\n", i+1, c.Issue.Org, c.Issue.Repo, c.Issue.Number, c.Issue.State, c.Issue.Title))
+		if c.Issue.Body != "" {
+			sb.WriteString(truncateText(c.Issue.Body, 500))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	prompt := fmt.Sprintf(`New Issue Title: %s
+
+New Issue Body:
+%s
+
+Candidates:
+%s
+Judge every candidate. Return JSON array only, no other text.`,
+		issue.Title,
+		truncateText(issue.Body, 2000),
+		sb.String())
+
+	response, err := r.llm.CompleteWithSystem(ctx, system, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("LLM rerank failed: %w", err)
+	}
+
+	judgements, err := parseJudgements(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return applyJudgements(candidates, judgements), nil
+}
+
+// parseJudgements parses the LLM response
+func parseJudgements(response string) ([]judgement, error) {
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var judgements []judgement
+	if err := json.Unmarshal([]byte(response), &judgements); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM rerank response: %w", err)
+	}
+	return judgements, nil
+}
+
+// applyJudgements drops unrelated candidates and orders same-root-cause
+// matches ahead of related ones, keeping similarity order within each group.
+// Candidates the LLM did not judge are kept as related.
+func applyJudgements(candidates []vectordb.SearchResult, judgements []judgement) []vectordb.SearchResult {
+	byID := make(map[int]judgement, len(judgements))
+	for _, j := range judgements {
+		byID[j.ID] = j
+	}
+
+	results := make([]vectordb.SearchResult, 0, len(candidates))
+	for i, c := range candidates {
+		j, ok := byID[i+1]
+		if !ok {
+			c.Relation = RelationRelated
+			results = append(results, c)
+			continue
+		}
+
+		relation := strings.ToLower(strings.TrimSpace(j.Relation))
+		if relation == RelationUnrelated {
+			continue
+		}
+		if relation != RelationSameRootCause {
+			relation = RelationRelated
+		}
+
+		c.Relation = relation
+		c.Reason = j.Reason
+		results = append(results, c)
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return results[a].Relation == RelationSameRootCause && results[b].Relation != RelationSameRootCause
+	})
+
+	return results
+}
@@ -0,0 +1,81 @@
+package rerank
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// mockLLM is a simple mock provider for testing
+type mockLLM struct {
+	response string
+	err      error
+}
+
+func (m *mockLLM) Complete(ctx context.Context, prompt string) (string, error) {
+	return m.response, m.err
+}
+
+func (m *mockLLM) CompleteWithSystem(ctx context.Context, system, prompt string) (string, error) {
+	return m.response, m.err
+}
+
+func (m *mockLLM) Close() error { return nil }
+
+func TestLLMReranker_Rerank(t *testing.T) {
+	candidates := []vectordb.SearchResult{
+		{Issue: models.Issue{Number: 1, Title: "Login page slow"}, Score: 0.91},
+		{Issue: models.Issue{Number: 2, Title: "Crash on login with SSO"}, Score: 0.88},
+		{Issue: models.Issue{Number: 3, Title: "Docs typo"}, Score: 0.85},
+		{Issue: models.Issue{Number: 4, Title: "SSO token refresh"}, Score: 0.84},
+	}
+
+	response := "```json\n" + `[
+		{"id": 1, "relation": "related", "reason": "Same page, different symptom"},
+		{"id": 2, "relation": "same_root_cause", "reason": "Same SSO nil pointer"},
+		{"id": 3, "relation": "unrelated", "reason": "Documentation"}
+	]` + "\n```"
+
+	r := NewLLMReranker(&mockLLM{response: response})
+	got, err := r.Rerank(context.Background(), &models.Issue{Title: "SSO login panics"}, candidates)
+	if err != nil {
+		t.Fatalf("Rerank() error = %v", err)
+	}
+
+	wantOrder := []int{2, 1, 4}
+	if len(got) != len(wantOrder) {
+		t.Fatalf("Rerank() returned %d results, want %d", len(got), len(wantOrder))
+	}
+	for i, n := range wantOrder {
+		if got[i].Issue.Number != n {
+			t.Errorf("result[%d] = #%d, want #%d", i, got[i].Issue.Number, n)
+		}
+	}
+
+	if got[0].Relation != RelationSameRootCause || got[0].Reason != "Same SSO nil pointer" {
+		t.Errorf("result[0] relation/reason = %q/%q", got[0].Relation, got[0].Reason)
+	}
+	if got[2].Relation != RelationRelated {
+		t.Errorf("unjudged candidate relation = %q, want %q", got[2].Relation, RelationRelated)
+	}
+}
+
+func TestApply_KeepsTail(t *testing.T) {
+	candidates := []vectordb.SearchResult{
+		{Issue: models.Issue{Number: 1}},
+		{Issue: models.Issue{Number: 2}},
+		{Issue: models.Issue{Number: 3}},
+	}
+
+	r := NewLLMReranker(&mockLLM{response: `[{"id": 1, "relation": "unrelated", "reason": "no"}]`})
+	got, err := Apply(context.Background(), r, &models.Issue{}, candidates, 2)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if len(got) != 2 || got[0].Issue.Number != 2 || got[1].Issue.Number != 3 {
+		t.Errorf("Apply() = %+v, want #2 then #3", got)
+	}
+}
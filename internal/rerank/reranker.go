@@ -0,0 +1,68 @@
+package rerank
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Relations assigned to candidates by re-ranking
+const (
+	RelationSameRootCause = "same_root_cause"
+	RelationRelated       = "related"
+	RelationUnrelated     = "unrelated"
+)
+
+// Reranker re-scores similarity candidates for an issue. Implementations
+// drop unrelated candidates and return the rest in their new order.
+type Reranker interface {
+	Rerank(ctx context.Context, issue *models.Issue, candidates []vectordb.SearchResult) ([]vectordb.SearchResult, error)
+}
+
+// New creates the reranker configured in cfg, or nil when re-ranking is disabled
+func New(cfg *config.RerankConfig, provider llm.Provider) (Reranker, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	switch cfg.Provider {
+	case "llm":
+		if provider == nil {
+			return nil, fmt.Errorf("llm reranker requires an LLM provider")
+		}
+		return NewLLMReranker(provider), nil
+	case "cross_encoder":
+		return NewCrossEncoderReranker(cfg.Endpoint, cfg.APIKey, cfg.MinScore), nil
+	default:
+		return nil, fmt.Errorf("unknown rerank provider: %s", cfg.Provider)
+	}
+}
+
+// Apply re-ranks the first topK candidates and appends the remainder unchanged
+func Apply(ctx context.Context, r Reranker, issue *models.Issue, candidates []vectordb.SearchResult, topK int) ([]vectordb.SearchResult, error) {
+	if topK <= 0 || topK > len(candidates) {
+		topK = len(candidates)
+	}
+
+	head := make([]vectordb.SearchResult, topK)
+	copy(head, candidates[:topK])
+
+	reranked, err := r.Rerank(ctx, issue, head)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(reranked, candidates[topK:]...), nil
+}
+
+// truncateText limits text length
+func truncateText(text string, maxLen int) string {
+	if len(text) <= maxLen {
+		return text
+	}
+	return text[:maxLen] + "..."
+}
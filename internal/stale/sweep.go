@@ -0,0 +1,201 @@
+// Package stale finds inactive issues and schedules them, through the
+// pending action mechanism, to be closed as duplicates of a newer or fixed
+// issue covering the same problem, or to receive a stale notice.
+package stale
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/triage"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// DefaultNotice is posted on stale issues when stale.notice is not set
+const DefaultNotice = "This issue has had no activity for %d days. It will stay open, but may be closed if nothing changes. Comment if it still affects you."
+
+// Outcomes of a sweep for a single issue
+const (
+	OutcomeDuplicate = "duplicate"
+	OutcomeStale     = "stale"
+)
+
+// maxCandidates is how many similar issues are considered as covering issues
+const maxCandidates = 5
+
+// pendingLabels mark issues that already have an action scheduled
+var pendingLabels = []string{
+	pending.LabelPendingTransfer,
+	pending.LabelPendingClose,
+	pending.LabelPendingAction,
+	pending.LabelPendingNeedsInfo,
+}
+
+// Candidate is a stale issue and what the sweep scheduled for it
+type Candidate struct {
+	Issue     *models.Issue `json:"issue"`
+	Outcome   string        `json:"outcome"`
+	CoveredBy *models.Issue `json:"covered_by,omitempty"`
+	Score     float64       `json:"score,omitempty"`
+}
+
+// Sweeper finds stale issues and schedules actions for them
+type Sweeper struct {
+	gh         *github.Client
+	vdb        *vectordb.Client
+	cfg        *config.Config
+	duplicates *triage.DuplicateChecker
+	executor   *triage.Executor
+	dryRun     bool
+}
+
+// NewSweeper creates a new stale issue sweeper
+func NewSweeper(gh *github.Client, vdb *vectordb.Client, cfg *config.Config, dryRun bool) *Sweeper {
+	duplicates := triage.NewDuplicateCheckerWithDelayedActionsAndDryRun(&cfg.Triage.Duplicate, gh, cfg, dryRun)
+	return &Sweeper{
+		gh:         gh,
+		vdb:        vdb,
+		cfg:        cfg,
+		duplicates: duplicates,
+		executor:   triage.NewExecutorWithDelayedActions(gh, cfg, duplicates, dryRun),
+		dryRun:     dryRun,
+	}
+}
+
+// Sweep schedules actions for the open issues of a repository that have
+// been inactive for stale.days. In dry run nothing is scheduled, but the
+// candidates are still returned.
+func (s *Sweeper) Sweep(ctx context.Context, repo config.RepositoryConfig, now time.Time) ([]Candidate, error) {
+	if !s.cfg.Defaults.DelayedActions.Enabled {
+		return nil, fmt.Errorf("the sweeper schedules pending actions, which requires defaults.delayed_actions.enabled")
+	}
+
+	issues, err := s.gh.ListAllIssues(ctx, repo.Org, repo.Repo, "open", 100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issues: %w", err)
+	}
+
+	cutoff := now.AddDate(0, 0, -s.cfg.Stale.Days)
+	exempt := append(append([]string{}, s.cfg.Stale.ExemptLabels...), repo.StaleExemptLabels...)
+	exempt = append(exempt, s.cfg.Stale.Label)
+	exempt = append(exempt, pendingLabels...)
+
+	var candidates []Candidate
+	for _, issue := range issues {
+		if !issue.UpdatedAt.Before(cutoff) || hasAnyLabel(issue.Labels, exempt) {
+			continue
+		}
+
+		candidate := Candidate{Issue: issue, Outcome: OutcomeStale}
+
+		results, err := s.vdb.SearchByIssue(ctx, vectordb.CollectionName(issue.Org), issue, maxCandidates, s.cfg.Stale.DuplicateThreshold)
+		if err != nil {
+			log.Printf("Warning: similarity search failed for #%d: %v", issue.Number, err)
+		}
+		if cover := FindCover(issue, s.configured(results), cutoff); cover != nil {
+			candidate.Outcome = OutcomeDuplicate
+			candidate.CoveredBy = &cover.Issue
+			candidate.Score = cover.Score
+		}
+
+		if !s.dryRun {
+			if err := s.schedule(ctx, candidate); err != nil {
+				log.Printf("Warning: failed to schedule %s action for #%d: %v", candidate.Outcome, issue.Number, err)
+				continue
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, nil
+}
+
+// schedule creates the pending action for a candidate
+func (s *Sweeper) schedule(ctx context.Context, c Candidate) error {
+	if c.Outcome == OutcomeDuplicate {
+		return s.duplicates.ScheduleClose(ctx, c.Issue, &triage.DuplicateResult{
+			IsDuplicate: true,
+			Similarity:  c.Score,
+			Original:    c.CoveredBy,
+			ShouldClose: true,
+			Rationale:   coverReason(c.CoveredBy, s.cfg.Stale.Days),
+		})
+	}
+
+	notice := s.cfg.Stale.Notice
+	if notice == "" {
+		notice = fmt.Sprintf(DefaultNotice, s.cfg.Stale.Days)
+	}
+	reason := fmt.Sprintf("no activity for %d days", s.cfg.Stale.Days)
+	return s.executor.ScheduleActions(ctx, c.Issue, []triage.Action{
+		{Type: triage.ActionAddLabel, Label: s.cfg.Stale.Label, Reason: reason},
+		{Type: triage.ActionComment, Comment: notice + "\n\n---\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>", Reason: reason},
+	})
+}
+
+// configured keeps the results from configured repositories, the only
+// ones a pending close may point at
+func (s *Sweeper) configured(results []vectordb.SearchResult) []vectordb.SearchResult {
+	var kept []vectordb.SearchResult
+	for _, r := range results {
+		for _, repo := range s.cfg.Repositories {
+			if strings.EqualFold(repo.Org, r.Issue.Org) && strings.EqualFold(repo.Repo, r.Issue.Repo) {
+				kept = append(kept, r)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// FindCover returns the best result that covers the same problem as a stale
+// issue: a newer issue that is still active, or one that was fixed. Results
+// are expected best first.
+func FindCover(issue *models.Issue, results []vectordb.SearchResult, cutoff time.Time) *vectordb.SearchResult {
+	for i := range results {
+		r := &results[i]
+		if r.Issue.Org == issue.Org && r.Issue.Repo == issue.Repo && r.Issue.Number == issue.Number {
+			continue
+		}
+
+		switch r.Issue.State {
+		case "open":
+			if r.Issue.CreatedAt.After(issue.CreatedAt) && r.Issue.UpdatedAt.After(cutoff) {
+				return r
+			}
+		case "closed":
+			// Older index entries carry no reason; GitHub's default is completed
+			if r.Issue.StateReason == "" || r.Issue.StateReason == github.CloseReasonCompleted {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// coverReason explains why a stale issue is closed in favor of another
+func coverReason(cover *models.Issue, days int) string {
+	if cover.State == "closed" {
+		return fmt.Sprintf("no activity for %d days and the same problem was fixed in #%d", days, cover.Number)
+	}
+	return fmt.Sprintf("no activity for %d days while the newer #%d tracks the same problem", days, cover.Number)
+}
+
+// hasAnyLabel reports whether any of labels is in exempt, ignoring case
+func hasAnyLabel(labels, exempt []string) bool {
+	for _, l := range labels {
+		for _, e := range exempt {
+			if strings.EqualFold(l, e) {
+				return true
+			}
+		}
+	}
+	return false
+}
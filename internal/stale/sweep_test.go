@@ -0,0 +1,65 @@
+package stale
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestFindCover(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -90)
+	issue := &models.Issue{Org: "org", Repo: "repo", Number: 10, CreatedAt: now.AddDate(-1, 0, 0)}
+
+	result := func(number int, state, reason string, created, updated time.Time) vectordb.SearchResult {
+		return vectordb.SearchResult{
+			Issue: models.Issue{Org: "org", Repo: "repo", Number: number, State: state, StateReason: reason, CreatedAt: created, UpdatedAt: updated},
+			Score: 0.95,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		results []vectordb.SearchResult
+		want    int
+	}{
+		{"no results", nil, 0},
+		{"itself", []vectordb.SearchResult{result(10, "open", "", now, now)}, 0},
+		{"newer active issue", []vectordb.SearchResult{result(20, "open", "", now.AddDate(0, -1, 0), now)}, 20},
+		{"newer but inactive", []vectordb.SearchResult{result(20, "open", "", now.AddDate(0, -6, 0), now.AddDate(0, -5, 0))}, 0},
+		{"older active issue", []vectordb.SearchResult{result(5, "open", "", now.AddDate(-2, 0, 0), now)}, 0},
+		{"fixed issue", []vectordb.SearchResult{result(3, "closed", "completed", now.AddDate(-2, 0, 0), now.AddDate(-1, 0, 0))}, 3},
+		{"closed without reason", []vectordb.SearchResult{result(3, "closed", "", now, now)}, 3},
+		{"not planned is skipped", []vectordb.SearchResult{
+			result(3, "closed", "not_planned", now, now),
+			result(4, "closed", "completed", now, now),
+		}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindCover(issue, tt.results, cutoff)
+			if tt.want == 0 {
+				if got != nil {
+					t.Errorf("FindCover() = #%d, want nil", got.Issue.Number)
+				}
+				return
+			}
+			if got == nil || got.Issue.Number != tt.want {
+				t.Errorf("FindCover() = %+v, want #%d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasAnyLabel(t *testing.T) {
+	exempt := []string{"pinned", "security"}
+	if !hasAnyLabel([]string{"bug", "Pinned"}, exempt) {
+		t.Error("expected pinned issue to be exempt")
+	}
+	if hasAnyLabel([]string{"bug"}, exempt) {
+		t.Error("expected bug issue not to be exempt")
+	}
+}
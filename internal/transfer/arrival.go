@@ -0,0 +1,155 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/audit"
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Classifier suggests label changes for an issue in the repository it is
+// in now
+type Classifier interface {
+	ClassifyLabels(ctx context.Context, issue *models.Issue) (add, remove []string, err error)
+}
+
+// SetClassifier sets the classifier used by rules with classify_on_arrival
+func (e *Executor) SetClassifier(c Classifier) {
+	e.classifier = c
+}
+
+// arrive finishes a move in the target repository: mapped labels replace
+// their source labels, the rule's add_labels are added, and the issue is
+// classified there when the rule asks for it
+func (e *Executor) arrive(ctx context.Context, issue *models.Issue, rule *config.TransferRule, targetRepo string, moved *github.TransferredIssue) {
+	if rule == nil || (len(rule.LabelMap) == 0 && len(rule.AddLabels) == 0 && !rule.ClassifyOnArrival) {
+		return
+	}
+	targetOrg, targetRepoName, err := github.ParseRepo(targetRepo)
+	if err != nil {
+		return
+	}
+
+	arrived, err := e.commentClient.GetIssue(ctx, targetOrg, targetRepoName, moved.Number)
+	if err != nil {
+		fmt.Printf("Warning: failed to get %s#%d after the move: %v\n", targetRepo, moved.Number, err)
+		return
+	}
+
+	present := arrived.Labels
+	add, remove := arrivalLabels(rule, issue.Labels)
+	arrived.Labels = applyLabels(present, add, remove)
+
+	if rule.ClassifyOnArrival {
+		if e.classifier == nil {
+			log.Printf("Skipping classification of %s#%d: triage is not enabled", targetRepo, moved.Number)
+		} else if suggested, conflicts, err := e.classifier.ClassifyLabels(ctx, arrived); err != nil {
+			fmt.Printf("Warning: failed to classify %s#%d: %v\n", targetRepo, moved.Number, err)
+		} else {
+			add = append(add, suggested...)
+			remove = append(remove, conflicts...)
+		}
+	}
+
+	// Only labels the issue still carries can be removed; GitHub drops the
+	// ones missing from the target repository during a transfer
+	for _, label := range remove {
+		if !containsLabel(present, label) {
+			continue
+		}
+		err := e.commentClient.RemoveLabel(ctx, targetOrg, targetRepoName, moved.Number, label)
+		e.recordArrival(targetOrg, targetRepoName, moved.Number, "remove_label", label, err)
+	}
+	if add = uniqueLabels(add); len(add) > 0 {
+		err := e.commentClient.AddLabels(ctx, targetOrg, targetRepoName, moved.Number, add)
+		for _, label := range add {
+			e.recordArrival(targetOrg, targetRepoName, moved.Number, "add_label", label, err)
+		}
+	}
+}
+
+// recordArrival audits a label change made on arrival
+func (e *Executor) recordArrival(org, repo string, number int, action, label string, err error) {
+	if err != nil {
+		fmt.Printf("Warning: failed to %s %q on %s/%s#%d: %v\n", strings.ReplaceAll(action, "_", " "), label, org, repo, number, err)
+	}
+	e.audit.Record(audit.Entry{
+		Org:     org,
+		Repo:    repo,
+		Issue:   number,
+		Action:  action,
+		Target:  label,
+		Rule:    "transfer arrival",
+		Outcome: audit.OutcomeOf(err, audit.OutcomeExecuted),
+		Error:   audit.ErrorText(err),
+	})
+}
+
+// arrivalLabels returns the label changes a rule makes on arrival: each
+// mapped source label is replaced by its target label, and add_labels are
+// added
+func arrivalLabels(rule *config.TransferRule, labels []string) (add, remove []string) {
+	for _, label := range labels {
+		mapped, ok := mappedLabel(rule, label)
+		if !ok {
+			continue
+		}
+		add = append(add, mapped)
+		if !strings.EqualFold(mapped, label) {
+			remove = append(remove, label)
+		}
+	}
+	add = append(add, rule.AddLabels...)
+	return uniqueLabels(add), remove
+}
+
+// mappedLabel returns the target label a rule maps a source label to
+func mappedLabel(rule *config.TransferRule, label string) (string, bool) {
+	if rule == nil {
+		return "", false
+	}
+	for from, to := range rule.LabelMap {
+		if strings.EqualFold(from, label) {
+			return to, true
+		}
+	}
+	return "", false
+}
+
+// applyLabels returns labels with remove taken out and add put in
+func applyLabels(labels, add, remove []string) []string {
+	var result []string
+	for _, l := range labels {
+		if !containsLabel(remove, l) {
+			result = append(result, l)
+		}
+	}
+	return uniqueLabels(append(result, add...))
+}
+
+// uniqueLabels drops repeated labels, ignoring case, keeping the first
+// spelling
+func uniqueLabels(labels []string) []string {
+	var unique []string
+	for _, l := range labels {
+		if !containsLabel(unique, l) {
+			unique = append(unique, l)
+		}
+	}
+	return unique
+}
+
+// containsLabel reports whether labels has label, ignoring case
+func containsLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
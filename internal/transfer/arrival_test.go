@@ -0,0 +1,46 @@
+package transfer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+)
+
+func TestArrivalLabels(t *testing.T) {
+	rule := &config.TransferRule{
+		LabelMap:  map[string]string{"bug": "type/bug", "Docs": "docs"},
+		AddLabels: []string{"triaged", "type/bug"},
+	}
+
+	tests := []struct {
+		name       string
+		labels     []string
+		wantAdd    []string
+		wantRemove []string
+	}{
+		{"no labels", nil, []string{"triaged", "type/bug"}, nil},
+		{"mapped label is replaced", []string{"BUG", "ui"}, []string{"type/bug", "triaged"}, []string{"BUG"}},
+		{"label mapped to itself is kept", []string{"docs"}, []string{"docs", "triaged", "type/bug"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			add, remove := arrivalLabels(rule, tt.labels)
+			if !reflect.DeepEqual(add, tt.wantAdd) {
+				t.Errorf("arrivalLabels() add = %v, want %v", add, tt.wantAdd)
+			}
+			if !reflect.DeepEqual(remove, tt.wantRemove) {
+				t.Errorf("arrivalLabels() remove = %v, want %v", remove, tt.wantRemove)
+			}
+		})
+	}
+}
+
+func TestApplyLabels(t *testing.T) {
+	got := applyLabels([]string{"bug", "ui", "Triaged"}, []string{"type/bug", "triaged"}, []string{"BUG"})
+	want := []string{"ui", "Triaged", "type/bug"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("applyLabels() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,219 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Indexer indexes the issues created by a copy transfer
+type Indexer interface {
+	IndexSingleIssue(ctx context.Context, issue *models.Issue) error
+}
+
+// SetIndexer sets the indexer used for copied issues. Without one, copies
+// are indexed by the next sync of the target repository.
+func (e *Executor) SetIndexer(idx Indexer) {
+	e.indexer = idx
+}
+
+// ruleFor returns the transfer rule of the issue's repository that targets
+// targetRepo, for transfers that were scheduled or routed without one
+func (e *Executor) ruleFor(issue *models.Issue, targetRepo string) *config.TransferRule {
+	repoCfg := e.cfg.GetRepoConfig(issue.Org, issue.Repo)
+	if repoCfg == nil {
+		return nil
+	}
+	for i := range repoCfg.TransferRules {
+		if strings.EqualFold(repoCfg.TransferRules[i].Target, targetRepo) {
+			return &repoCfg.TransferRules[i]
+		}
+	}
+	return nil
+}
+
+// shouldCopy reports whether a rule's strategy copies the issue instead of
+// transferring it
+func shouldCopy(rule *config.TransferRule, sourceOrg, targetOrg string) bool {
+	switch rule.TransferStrategy() {
+	case "copy":
+		return true
+	case "transfer":
+		return false
+	default:
+		return !strings.EqualFold(sourceOrg, targetOrg)
+	}
+}
+
+// copyIssue recreates an issue in targetRepo and closes the original with a
+// pointer to the copy. Labels the rule maps are left to arrive.
+func (e *Executor) copyIssue(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule) (*github.TransferredIssue, error) {
+	targetOrg, targetRepoName, err := github.ParseRepo(targetRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	var unmapped []string
+	for _, label := range issue.Labels {
+		if _, ok := mappedLabel(rule, label); !ok {
+			unmapped = append(unmapped, label)
+		}
+	}
+
+	labels := e.targetLabels(ctx, targetOrg, targetRepoName, unmapped)
+	created, err := e.transferClient.CreateIssue(ctx, targetOrg, targetRepoName, issue.Title, formatCopiedBody(issue), labels)
+	if err != nil {
+		return nil, err
+	}
+	copied := &github.TransferredIssue{Number: created.Number, URL: created.HTMLURL, NodeID: created.NodeID}
+
+	if rule != nil && rule.CopyComments {
+		e.copyComments(ctx, issue, targetOrg, targetRepoName, copied.Number)
+	}
+
+	// The copy exists, so failing to close the original is not fatal
+	if err := e.commentClient.PostComment(ctx, issue.Org, issue.Repo, issue.Number, formatCopiedComment(targetRepo, copied)); err != nil {
+		fmt.Printf("Warning: failed to link %s/%s#%d to its copy: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	}
+	if err := e.commentClient.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, github.CloseReasonNotPlanned); err != nil {
+		fmt.Printf("Warning: failed to close %s/%s#%d after copying it: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	}
+
+	return copied, nil
+}
+
+// targetLabels keeps the labels that exist in the target repository, using
+// its spelling, and drops simili's pending labels
+func (e *Executor) targetLabels(ctx context.Context, org, repo string, labels []string) []string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	available, err := e.transferClient.ListRepoLabels(ctx, org, repo)
+	if err != nil {
+		fmt.Printf("Warning: failed to list labels of %s/%s, copying without labels: %v\n", org, repo, err)
+		return nil
+	}
+
+	var kept []string
+	for _, label := range labels {
+		if isPendingLabel(label) {
+			continue
+		}
+		for _, l := range available {
+			if strings.EqualFold(l.Name, label) {
+				kept = append(kept, l.Name)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// isPendingLabel reports whether a label marks a pending action
+func isPendingLabel(label string) bool {
+	switch label {
+	case pending.LabelPendingTransfer, pending.LabelPendingClose, pending.LabelPendingAction, pending.LabelPendingNeedsInfo:
+		return true
+	}
+	return false
+}
+
+// copyComments posts the comments of an issue on its copy, oldest first,
+// leaving out simili's own
+func (e *Executor) copyComments(ctx context.Context, issue *models.Issue, org, repo string, number int) {
+	comments, err := e.commentClient.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		fmt.Printf("Warning: failed to list comments of %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+		return
+	}
+
+	for _, c := range comments {
+		if e.trust.Trusted(ctx, c.User) {
+			continue
+		}
+		body := fmt.Sprintf("> **@%s** commented on %s:\n\n%s", c.User.Login, c.CreatedAt.Format("2006-01-02"), c.Body)
+		if err := e.commentClient.PostComment(ctx, org, repo, number, body); err != nil {
+			fmt.Printf("Warning: failed to copy comment %d to %s/%s#%d: %v\n", c.ID, org, repo, number, err)
+		}
+	}
+}
+
+// indexMoved adds an issue that got a new identity, a copy or a reopened
+// original, to the vector database
+func (e *Executor) indexMoved(ctx context.Context, targetRepo string, copied *github.TransferredIssue) {
+	if e.indexer == nil {
+		log.Printf("Issue %s#%d will be indexed by the next sync", targetRepo, copied.Number)
+		return
+	}
+
+	targetOrg, targetRepoName, err := github.ParseRepo(targetRepo)
+	if err != nil {
+		return
+	}
+	issue, err := e.commentClient.GetIssue(ctx, targetOrg, targetRepoName, copied.Number)
+	if err != nil {
+		fmt.Printf("Warning: failed to get issue %s#%d for indexing: %v\n", targetRepo, copied.Number, err)
+		return
+	}
+	if err := e.indexer.IndexSingleIssue(ctx, issue); err != nil {
+		fmt.Printf("Warning: failed to index issue %s#%d: %v\n", targetRepo, copied.Number, err)
+	}
+}
+
+// revertCopy undoes a copy: the original issue is reopened, the copy closed
+// with a pointer back to it, and the move recorded on the original
+func (e *Executor) revertCopy(ctx context.Context, issue *models.Issue, original IssueRef, comment string) (*github.TransferredIssue, error) {
+	previous := e.currentMapping(ctx, issue)
+
+	if err := e.commentClient.ReopenIssue(ctx, original.Org, original.Repo, original.Number); err != nil {
+		return nil, fmt.Errorf("failed to reopen original issue: %w", err)
+	}
+	if err := e.commentClient.PostComment(ctx, issue.Org, issue.Repo, issue.Number, comment); err != nil {
+		fmt.Printf("Warning: failed to post revert comment on %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	}
+	if err := e.commentClient.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, github.CloseReasonNotPlanned); err != nil {
+		fmt.Printf("Warning: failed to close copy %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	}
+
+	collection := vectordb.CollectionName(issue.Org)
+	if err := e.vectordb.DeleteIssuePoints(ctx, collection, issue.Org, issue.Repo, issue.Number); err != nil {
+		fmt.Printf("Warning: failed to delete vector of copy: %v\n", err)
+	}
+
+	moved := &github.TransferredIssue{Number: original.Number, URL: original.URL, NodeID: original.NodeID}
+	if err := e.recordMapping(ctx, issue, original.FullRepo(), moved, previous, Hop{Revert: true, Copy: true}); err != nil {
+		fmt.Printf("Warning: failed to record transfer mapping of %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	}
+	e.indexMoved(ctx, original.FullRepo(), moved)
+
+	return moved, nil
+}
+
+// formatCopiedBody creates the body of a copied issue, crediting its author
+func formatCopiedBody(issue *models.Issue) string {
+	source := fmt.Sprintf("%s/%s#%d", issue.Org, issue.Repo, issue.Number)
+	if issue.URL != "" {
+		source = fmt.Sprintf("[%s](%s)", source, issue.URL)
+	}
+	return fmt.Sprintf("> Copied from %s, originally opened by @%s.\n\n%s", source, issue.Author, issue.Body)
+}
+
+// formatCopiedComment creates the comment closing the original of a copy
+func formatCopiedComment(targetRepo string, copied *github.TransferredIssue) string {
+	link := fmt.Sprintf("%s#%d", targetRepo, copied.Number)
+	if copied.URL != "" {
+		link = fmt.Sprintf("[%s](%s)", link, copied.URL)
+	}
+	return fmt.Sprintf(`📋 This issue was copied to **%s** as %s and is closed here. Please continue the discussion there.
+
+---
+<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, targetRepo, link)
+}
@@ -0,0 +1,44 @@
+package transfer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestShouldCopy(t *testing.T) {
+	tests := []struct {
+		name      string
+		rule      *config.TransferRule
+		targetOrg string
+		want      bool
+	}{
+		{"no rule, same owner", nil, "org", false},
+		{"no rule, other owner", nil, "sister", true},
+		{"auto ignores owner case", &config.TransferRule{Strategy: "auto"}, "ORG", false},
+		{"transfer never copies", &config.TransferRule{Strategy: "transfer"}, "sister", false},
+		{"copy always copies", &config.TransferRule{Strategy: "copy"}, "org", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldCopy(tt.rule, "org", tt.targetOrg); got != tt.want {
+				t.Errorf("shouldCopy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatCopiedBody(t *testing.T) {
+	issue := &models.Issue{Org: "org", Repo: "a", Number: 3, URL: "https://github.com/org/a/issues/3", Author: "octocat", Body: "It crashes."}
+
+	body := formatCopiedBody(issue)
+	if !strings.Contains(body, "[org/a#3](https://github.com/org/a/issues/3)") || !strings.Contains(body, "@octocat") {
+		t.Errorf("formatCopiedBody() = %q, want a link back and the author", body)
+	}
+	if !strings.HasSuffix(body, "It crashes.") {
+		t.Errorf("formatCopiedBody() = %q, want the original body", body)
+	}
+}
@@ -3,9 +3,11 @@ package transfer
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/Kavirubc/gh-simili/internal/audit"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/pending"
@@ -19,6 +21,10 @@ type Executor struct {
 	commentClient  *github.Client // Client for posting comments (bot identity)
 	vectordb       *vectordb.Client
 	pendingManager *pending.Manager
+	trust          *pending.CommentStore // recognizes simili's own mapping comments
+	indexer        Indexer               // indexes copied issues; may be nil
+	classifier     Classifier            // classifies issues on arrival; may be nil
+	audit          *audit.Log
 	cfg            *config.Config
 	dryRun         bool
 }
@@ -32,14 +38,24 @@ func NewExecutor(transferClient *github.Client, commentClient *github.Client, vd
 		commentClient:  commentClient,
 		vectordb:       vdb,
 		pendingManager: pending.NewManager(commentClient, cfg),
+		trust:          pending.NewCommentStore(commentClient, &cfg.Defaults.DelayedActions),
+		audit:          audit.New(cfg),
 		cfg:            cfg,
 		dryRun:         dryRun,
 	}
 }
 
+// Routing is the router decision behind a transfer, recorded in the audit
+// log. It mirrors triage.RoutingResult, which cannot be imported here.
+type Routing struct {
+	Confidence float64
+	Reason     string
+}
+
 // Transfer executes an issue transfer to target repository
-// If delayed actions are enabled, schedules the transfer instead of executing immediately
-func (e *Executor) Transfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule) error {
+// If delayed actions are enabled, schedules the transfer instead of executing immediately.
+// routing is nil unless the router chose the target.
+func (e *Executor) Transfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule, routing *Routing) error {
 	targetOrg, targetRepoName, err := github.ParseRepo(targetRepo)
 	if err != nil {
 		return err
@@ -69,7 +85,7 @@ func (e *Executor) Transfer(ctx context.Context, issue *models.Issue, targetRepo
 	}
 
 	// Immediate transfer (original behavior)
-	return e.executeTransfer(ctx, issue, targetRepo, rule)
+	return e.executeTransfer(ctx, issue, targetRepo, rule, routing, nil)
 }
 
 // ScheduleTransfer schedules a delayed transfer
@@ -132,74 +148,198 @@ func (e *Executor) ProcessPendingTransfer(ctx context.Context, action *pending.P
 	}
 	if transferred {
 		// Already transferred, just remove label
-		return e.pendingManager.Cancel(ctx, action)
+		return e.pendingManager.Complete(ctx, action)
+	}
+
+	if err := e.pendingManager.Validate(ctx, action); err != nil {
+		return err
 	}
 
 	// Check reactions
-	decision, err := e.commentClient.CheckReactionDecision(
-		ctx,
-		action.Org,
-		action.Repo,
-		action.CommentID,
-		e.cfg.Defaults.DelayedActions.ApproveReaction,
-		e.cfg.Defaults.DelayedActions.CancelReaction,
-	)
+	decision, err := e.pendingManager.Decide(ctx, action)
 	if err != nil {
 		return fmt.Errorf("failed to check reactions: %w", err)
 	}
 
-	if decision == "cancel" {
+	if decision.Outcome == pending.DecisionCancel {
 		// User cancelled, remove label and post cancellation comment
-		if err := e.pendingManager.Cancel(ctx, action); err != nil {
-			return err
-		}
-		cancelComment := formatTransferCancelledComment(action.Target)
-		return e.commentClient.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, cancelComment)
+		return e.CancelPendingTransfer(ctx, action, decision.DecidedBy)
 	}
 
-	if decision == "approve" && e.cfg.Defaults.DelayedActions.ExecuteOnApprove {
+	if decision.Outcome == pending.DecisionApprove && e.cfg.Defaults.DelayedActions.ExecuteOnApprove {
 		// User approved, execute immediately
-		issue := &models.Issue{
-			Org:    action.Org,
-			Repo:   action.Repo,
-			Number: action.IssueNumber,
-		}
-		return e.executeTransfer(ctx, issue, action.Target, nil)
+		fmt.Printf("Transfer of %s/%s#%d approved by %s\n", action.Org, action.Repo, action.IssueNumber, pending.FormatDecidedBy(decision.DecidedBy))
+		return e.ApprovePendingTransfer(ctx, action, decision.DecidedBy)
 	}
 
 	if action.IsExpired() {
 		// Expired and no cancel reaction, execute transfer
-		issue := &models.Issue{
-			Org:    action.Org,
-			Repo:   action.Repo,
-			Number: action.IssueNumber,
-		}
-		return e.executeTransfer(ctx, issue, action.Target, nil)
+		return e.ApprovePendingTransfer(ctx, action, nil)
 	}
 
 	return nil // Not expired yet
 }
 
-// executeTransfer performs the actual transfer
-func (e *Executor) executeTransfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule) error {
+// ApprovePendingTransfer executes a pending transfer without waiting for it
+// to expire. approvedBy is recorded when users approved it explicitly.
+func (e *Executor) ApprovePendingTransfer(ctx context.Context, action *pending.PendingAction, approvedBy []string) error {
+	if e.dryRun {
+		return nil
+	}
+
+	if err := e.pendingManager.Validate(ctx, action); err != nil {
+		return err
+	}
+
+	if len(approvedBy) > 0 {
+		if err := e.pendingManager.Record(ctx, action, pending.StateDecided, approvedBy); err != nil {
+			return err
+		}
+	}
+
+	issue := &models.Issue{
+		Org:    action.Org,
+		Repo:   action.Repo,
+		Number: action.IssueNumber,
+	}
+	if err := e.executeTransfer(ctx, issue, action.Target, nil, nil, approvedBy); err != nil {
+		return err
+	}
+	return e.pendingManager.Record(ctx, action, pending.StateExecuted, nil)
+}
+
+// CancelPendingTransfer cancels a pending transfer on behalf of the users who decided
+func (e *Executor) CancelPendingTransfer(ctx context.Context, action *pending.PendingAction, decidedBy []string) error {
+	if e.dryRun {
+		return nil
+	}
+
+	if err := e.pendingManager.Record(ctx, action, pending.StateDecided, decidedBy); err != nil {
+		return err
+	}
+	if err := e.pendingManager.Cancel(ctx, action); err != nil {
+		return err
+	}
+	cancelComment := formatTransferCancelledComment(action.Target, decidedBy)
+	return e.commentClient.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, cancelComment)
+}
+
+// TransferNow transfers an issue immediately, bypassing delayed actions.
+// It is used when a maintainer asks for the transfer, and like every other
+// transfer it may only move the issue to another configured repository.
+func (e *Executor) TransferNow(ctx context.Context, issue *models.Issue, targetRepo string, requestedBy string) error {
+	if err := pending.ValidateTarget(e.cfg, &pending.PendingAction{
+		Type:   pending.ActionTypeTransfer,
+		Org:    issue.Org,
+		Repo:   issue.Repo,
+		Target: targetRepo,
+	}); err != nil {
+		return err
+	}
+
+	targetOrg, targetRepoName, err := github.ParseRepo(targetRepo)
+	if err != nil {
+		return err
+	}
+
+	exists, err := e.transferClient.RepoExists(ctx, targetOrg, targetRepoName)
+	if err != nil {
+		return fmt.Errorf("failed to check target repo: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("target repo %s does not exist", targetRepo)
+	}
+
+	return e.executeTransfer(ctx, issue, targetRepo, nil, nil, []string{requestedBy})
+}
+
+// executeTransfer performs the actual transfer and audits it. routing is
+// set when the router chose the target; approvedBy lists the users who asked
+// for it, if any.
+func (e *Executor) executeTransfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule, routing *Routing, approvedBy []string) error {
 	if e.dryRun {
 		return nil
 	}
 
-	// Post transfer comment
 	var comment string
 	if e.cfg.Defaults.DelayedActions.Enabled && e.cfg.Defaults.DelayedActions.OptimisticTransfers {
 		comment = formatOptimisticTransferComment(issue, targetRepo, rule, e.cfg.Defaults.DelayedActions.CancelReaction)
 	} else {
 		comment = formatTransferComment(targetRepo, rule)
 	}
+
+	if rule == nil {
+		rule = e.ruleFor(issue, targetRepo)
+	}
+	moved, err := e.transfer(ctx, issue, targetRepo, rule, comment, false)
+	entry := audit.Entry{
+		Org:     issue.Org,
+		Repo:    issue.Repo,
+		Issue:   issue.Number,
+		Action:  "transfer",
+		Target:  movedTarget(targetRepo, moved),
+		Actor:   audit.Actor(approvedBy),
+		Outcome: audit.OutcomeOf(err, audit.OutcomeExecuted),
+		Error:   audit.ErrorText(err),
+	}
+	entry.Rule, entry.Confidence = transferReason(rule, routing)
+	e.audit.Record(entry)
+	return err
+}
+
+// transferReason returns why an issue was transferred and how confident the
+// router was, for the audit log
+func transferReason(rule *config.TransferRule, routing *Routing) (string, float64) {
+	switch {
+	case routing != nil:
+		return routing.Reason, routing.Confidence
+	case rule != nil:
+		return formatMatchDescription(rule), 0
+	}
+	return "", 0
+}
+
+// transfer posts comment on the issue, moves it and records the move in a
+// mapping comment at its new location. Depending on the rule's strategy the
+// issue is transferred, or copied and the original closed. revert marks a
+// move back to where the issue came from.
+func (e *Executor) transfer(ctx context.Context, issue *models.Issue, targetRepo string, rule *config.TransferRule, comment string, revert bool) (*github.TransferredIssue, error) {
+	targetOrg, _, err := github.ParseRepo(targetRepo)
+	if err != nil {
+		return nil, err
+	}
+	copied := shouldCopy(rule, issue.Org, targetOrg)
+
+	// Pending transfers only know the issue number, but copies and label
+	// mappings need the rest
+	if issue.Title == "" {
+		full, err := e.commentClient.GetIssue(ctx, issue.Org, issue.Repo, issue.Number)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get issue: %w", err)
+		}
+		issue = full
+	}
+
+	// Read the earlier moves before the issue changes place
+	previous := e.currentMapping(ctx, issue)
+
+	// Post transfer comment
 	if err := e.commentClient.PostComment(ctx, issue.Org, issue.Repo, issue.Number, comment); err != nil {
-		return fmt.Errorf("failed to post transfer comment: %w", err)
+		return nil, fmt.Errorf("failed to post transfer comment: %w", err)
 	}
 
 	// Execute transfer
-	if err := e.transferClient.TransferIssue(ctx, issue.Org, issue.Repo, issue.Number, targetRepo); err != nil {
-		return fmt.Errorf("failed to transfer issue: %w", err)
+	var moved *github.TransferredIssue
+	if copied {
+		moved, err = e.copyIssue(ctx, issue, targetRepo, rule)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy issue: %w", err)
+		}
+	} else {
+		moved, err = e.transferClient.TransferIssue(ctx, issue.Org, issue.Repo, issue.Number, targetRepo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to transfer issue: %w", err)
+		}
 	}
 
 	// Remove pending label if exists
@@ -207,15 +347,75 @@ func (e *Executor) executeTransfer(ctx context.Context, issue *models.Issue, tar
 		fmt.Printf("Warning: failed to remove pending-transfer label from %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
 	}
 
-	// Delete old vector
+	// Delete old vector (and any comment points linked to it)
 	collection := vectordb.CollectionName(issue.Org)
-	if err := e.vectordb.Delete(ctx, collection, issue.UUID()); err != nil {
+	if err := e.vectordb.DeleteIssuePoints(ctx, collection, issue.Org, issue.Repo, issue.Number); err != nil {
 		fmt.Printf("Warning: failed to delete old vector: %v\n", err)
 	}
 
+	if err := e.recordMapping(ctx, issue, targetRepo, moved, previous, Hop{Revert: revert, Copy: copied}); err != nil {
+		fmt.Printf("Warning: failed to record transfer mapping of %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+	}
+
+	if !revert {
+		e.arrive(ctx, issue, rule, targetRepo, moved)
+	}
+	if copied {
+		e.indexMoved(ctx, targetRepo, moved)
+	}
+
+	return moved, nil
+}
+
+// currentMapping returns the moves recorded on an issue so far, or nil
+func (e *Executor) currentMapping(ctx context.Context, issue *models.Issue) *Mapping {
+	comments, err := e.commentClient.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		fmt.Printf("Warning: failed to read transfer mapping of %s/%s#%d: %v\n", issue.Org, issue.Repo, issue.Number, err)
+		return nil
+	}
+	if found := findMapping(ctx, comments, e.trust, e.cfg.Defaults.DelayedActions.SigningSecret); found != nil {
+		return found.Mapping
+	}
 	return nil
 }
 
+// recordMapping posts the link back to the source on the moved issue,
+// carrying the mapping with the new hop. kind sets how the issue moved.
+func (e *Executor) recordMapping(ctx context.Context, issue *models.Issue, targetRepo string, moved *github.TransferredIssue, previous *Mapping, kind Hop) error {
+	if moved == nil || moved.Number == 0 {
+		return fmt.Errorf("transfer did not return the new issue")
+	}
+	targetOrg, targetRepoName, err := github.ParseRepo(targetRepo)
+	if err != nil {
+		return err
+	}
+
+	m := previous.extend(Hop{
+		From:   IssueRef{Org: issue.Org, Repo: issue.Repo, Number: issue.Number, URL: issue.URL},
+		To:     IssueRef{Org: targetOrg, Repo: targetRepoName, Number: moved.Number, URL: moved.URL, NodeID: moved.NodeID},
+		Revert: kind.Revert,
+		Copy:   kind.Copy,
+		At:     time.Now().UTC(),
+	})
+
+	cfg := e.cfg.Defaults.DelayedActions
+	offerRevert := cfg.Enabled && cfg.OptimisticTransfers
+	comment, err := formatMappingComment(m, offerRevert, cfg.CancelReaction, cfg.SigningSecret)
+	if err != nil {
+		return err
+	}
+	return e.commentClient.PostComment(ctx, targetOrg, targetRepoName, moved.Number, comment)
+}
+
+// movedTarget describes where an issue went, with its new number when known
+func movedTarget(targetRepo string, moved *github.TransferredIssue) string {
+	if moved == nil || moved.Number == 0 {
+		return targetRepo
+	}
+	return fmt.Sprintf("%s#%d", targetRepo, moved.Number)
+}
+
 // formatTransferComment creates the transfer notification comment
 func formatTransferComment(targetRepo string, rule *config.TransferRule) string {
 	matchDesc := formatMatchDescription(rule)
@@ -235,7 +435,7 @@ func formatDelayedTransferComment(targetRepo string, rule *config.TransferRule,
 	matchDesc := formatMatchDescription(rule)
 	deadline := expiresAt.Format("2006-01-02 15:04 MST")
 
-	metadata, err := pending.FormatPendingActionMetadata(action)
+	metadata, err := pending.FormatPendingActionMetadata(action, cfg.SigningSecret)
 	if err != nil {
 		return "", err
 	}
@@ -247,7 +447,7 @@ func formatDelayedTransferComment(targetRepo string, rule *config.TransferRule,
 **React to this comment:**
 - 👍 (%s) to approve and proceed with this transfer
 - 👎 (%s) to cancel this transfer
-
+%s
 **Deadline**: %s
 
 If no reaction is provided, the transfer will proceed automatically.
@@ -261,19 +461,20 @@ If no reaction is provided, the transfer will proceed automatically.
 		matchDesc,
 		cfg.ApproveReaction,
 		cfg.CancelReaction,
+		pending.FormatAuthorization(cfg.Authorization),
 		deadline,
 		metadata,
 	), nil
 }
 
 // formatTransferCancelledComment creates a cancellation comment
-func formatTransferCancelledComment(targetRepo string) string {
-	return fmt.Sprintf(`✅ Transfer to **%s** has been cancelled based on your reaction.
+func formatTransferCancelledComment(targetRepo string, decidedBy []string) string {
+	return fmt.Sprintf(`✅ Transfer to **%s** has been cancelled by %s.
 
 The issue will remain in this repository.
 
 ---
-<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, targetRepo)
+<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, targetRepo, pending.FormatDecidedBy(decidedBy))
 }
 
 // formatMatchDescription creates a human-readable match description
@@ -282,27 +483,84 @@ func formatMatchDescription(rule *config.TransferRule) string {
 		return "routing rules"
 	}
 
+	desc := describeCondition(&rule.Match)
+	if desc == "" {
+		return "routing rules"
+	}
+	return desc
+}
+
+// describeCondition renders a match condition and its nested conditions
+func describeCondition(cond *config.MatchCondition) string {
 	var parts []string
 
-	if len(rule.Match.Labels) > 0 {
-		parts = append(parts, fmt.Sprintf("`labels: [%s]`", strings.Join(rule.Match.Labels, ", ")))
+	if len(cond.Labels) > 0 {
+		parts = append(parts, fmt.Sprintf("`labels: [%s]`", strings.Join(cond.Labels, ", ")))
 	}
-	if len(rule.Match.TitleContains) > 0 {
-		parts = append(parts, fmt.Sprintf("`title_contains: [%s]`", strings.Join(rule.Match.TitleContains, ", ")))
+	if len(cond.TitleContains) > 0 {
+		parts = append(parts, fmt.Sprintf("`title_contains: [%s]`", strings.Join(cond.TitleContains, ", ")))
 	}
-	if len(rule.Match.BodyContains) > 0 {
-		parts = append(parts, fmt.Sprintf("`body_contains: [%s]`", strings.Join(rule.Match.BodyContains, ", ")))
+	if len(cond.BodyContains) > 0 {
+		parts = append(parts, fmt.Sprintf("`body_contains: [%s]`", strings.Join(cond.BodyContains, ", ")))
 	}
-	if rule.Match.Author != "" {
-		parts = append(parts, fmt.Sprintf("`author: %s`", rule.Match.Author))
+	if cond.TitleRegex != "" {
+		parts = append(parts, fmt.Sprintf("`title_regex: /%s/`", cond.TitleRegex))
 	}
-
-	if len(parts) == 0 {
-		return "routing rules"
+	if cond.BodyRegex != "" {
+		parts = append(parts, fmt.Sprintf("`body_regex: /%s/`", cond.BodyRegex))
+	}
+	if cond.Author != "" {
+		parts = append(parts, fmt.Sprintf("`author: %s`", cond.Author))
+	}
+	if len(cond.AuthorAssociation) > 0 {
+		parts = append(parts, fmt.Sprintf("`author_association: [%s]`", strings.Join(cond.AuthorAssociation, ", ")))
 	}
+	if len(cond.IssueType) > 0 {
+		parts = append(parts, fmt.Sprintf("`issue_type: [%s]`", strings.Join(cond.IssueType, ", ")))
+	}
+	if len(cond.Fields) > 0 {
+		labels := make([]string, 0, len(cond.Fields))
+		for label := range cond.Fields {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			parts = append(parts, fmt.Sprintf("`%s: [%s]`", label, strings.Join(cond.Fields[label], ", ")))
+		}
+	}
+	if len(cond.Keywords) > 0 {
+		patterns := make([]string, len(cond.Keywords))
+		for i, kw := range cond.Keywords {
+			patterns[i] = kw.Pattern
+		}
+		parts = append(parts, fmt.Sprintf("`keywords: [%s]`", strings.Join(patterns, ", ")))
+	}
+	if cond.Not != nil {
+		if desc := describeCondition(cond.Not); desc != "" {
+			parts = append(parts, "not ("+desc+")")
+		}
+	}
+	if len(cond.AnyOf) > 0 {
+		parts = append(parts, "any of ("+describeConditions(cond.AnyOf, " | ")+")")
+	}
+	if len(cond.AllOf) > 0 {
+		parts = append(parts, "all of ("+describeConditions(cond.AllOf, " + ")+")")
+	}
+
 	return strings.Join(parts, " + ")
 }
 
+// describeConditions renders a list of nested conditions
+func describeConditions(conds []config.MatchCondition, sep string) string {
+	descs := make([]string, 0, len(conds))
+	for i := range conds {
+		if desc := describeCondition(&conds[i]); desc != "" {
+			descs = append(descs, desc)
+		}
+	}
+	return strings.Join(descs, sep)
+}
+
 // TransferSourceMetadata represents metadata about where a transfer came from
 type TransferSourceMetadata struct {
 	Org  string `json:"org"`
@@ -0,0 +1,59 @@
+package transfer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestTransferNowRequiresConfiguredTarget(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "a"}, {Org: "org", Repo: "b"}}}
+	e := &Executor{cfg: cfg}
+	issue := &models.Issue{Org: "org", Repo: "a", Number: 7}
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "org/private-repo", want: "not a configured repository"},
+		{target: "org/a", want: "own repository"},
+		{target: "not-a-repo", want: "invalid repo format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			err := e.TransferNow(context.Background(), issue, tt.target, "maintainer")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("TransferNow(%q) error = %v, want %q", tt.target, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransferReason(t *testing.T) {
+	rule := &config.TransferRule{Target: "org/b", Match: config.MatchCondition{Labels: []string{"backend"}}}
+
+	tests := []struct {
+		name           string
+		rule           *config.TransferRule
+		routing        *Routing
+		wantRule       string
+		wantConfidence float64
+	}{
+		{name: "router", rule: rule, routing: &Routing{Confidence: 0.82, Reason: "Mentions the API server"}, wantRule: "Mentions the API server", wantConfidence: 0.82},
+		{name: "rule", rule: rule, wantRule: "`labels: [backend]`"},
+		{name: "maintainer", wantRule: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRule, gotConfidence := transferReason(tt.rule, tt.routing)
+			if gotRule != tt.wantRule || gotConfidence != tt.wantConfidence {
+				t.Errorf("transferReason() = %q, %v; want %q, %v", gotRule, gotConfidence, tt.wantRule, tt.wantConfidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package transfer
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+)
+
+const (
+	mappingMetadataPattern = `<!-- simili-transfer-mapping: ({.*?})(?: sig:([0-9a-f]+))? -->`
+)
+
+var mappingMetadataRegex = regexp.MustCompile(`(?s)` + mappingMetadataPattern)
+
+// IssueRef identifies an issue at one of its locations
+type IssueRef struct {
+	Org    string `json:"org"`
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	URL    string `json:"url,omitempty"`
+	NodeID string `json:"node_id,omitempty"`
+}
+
+// FullRepo returns the repository as org/repo
+func (r IssueRef) FullRepo() string {
+	return r.Org + "/" + r.Repo
+}
+
+// Hop is one move of an issue between repositories
+type Hop struct {
+	From   IssueRef  `json:"from"`
+	To     IssueRef  `json:"to"`
+	Revert bool      `json:"revert,omitempty"`
+	Copy   bool      `json:"copy,omitempty"` // the issue was recreated and the original closed
+	At     time.Time `json:"at"`
+}
+
+// Mapping records every move of a transferred issue, oldest first. It is
+// stored in a comment on the moved issue, and each new comment carries the
+// hops before it, so the chain survives even if older comments are lost.
+type Mapping struct {
+	Hops []Hop `json:"hops"`
+}
+
+// Last returns the most recent hop, or nil
+func (m *Mapping) Last() *Hop {
+	if m == nil || len(m.Hops) == 0 {
+		return nil
+	}
+	return &m.Hops[len(m.Hops)-1]
+}
+
+// Visited reports whether the issue has already been in a repository
+func (m *Mapping) Visited(repo string) bool {
+	if m == nil {
+		return false
+	}
+	for _, h := range m.Hops {
+		if strings.EqualFold(h.From.FullRepo(), repo) || strings.EqualFold(h.To.FullRepo(), repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAt reports whether the last hop left the issue in the given repository
+func (m *Mapping) IsAt(org, repo string) bool {
+	last := m.Last()
+	return last != nil && strings.EqualFold(last.To.Org, org) && strings.EqualFold(last.To.Repo, repo)
+}
+
+// extend returns the mapping with one more hop
+func (m *Mapping) extend(hop Hop) *Mapping {
+	next := &Mapping{}
+	if m != nil {
+		next.Hops = append(next.Hops, m.Hops...)
+	}
+	next.Hops = append(next.Hops, hop)
+	return next
+}
+
+// FormatMappingMetadata formats a mapping as HTML comment. With a secret, an
+// HMAC-SHA256 signature of the JSON is appended.
+func FormatMappingMetadata(m *Mapping, secret string) (string, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal transfer mapping: %w", err)
+	}
+	if secret == "" {
+		return fmt.Sprintf("<!-- simili-transfer-mapping: %s -->", string(data)), nil
+	}
+	return fmt.Sprintf("<!-- simili-transfer-mapping: %s sig:%s -->", string(data), pending.Sign(data, secret)), nil
+}
+
+// ParseMappingMetadata parses a mapping from comment body. With a secret,
+// the signature must match.
+func ParseMappingMetadata(commentBody string, secret string) (*Mapping, error) {
+	matches := mappingMetadataRegex.FindStringSubmatch(commentBody)
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("transfer mapping not found")
+	}
+
+	if secret != "" && !hmac.Equal([]byte(matches[2]), []byte(pending.Sign([]byte(matches[1]), secret))) {
+		return nil, pending.ErrInvalidSignature
+	}
+
+	var m Mapping
+	if err := json.Unmarshal([]byte(matches[1]), &m); err != nil {
+		return nil, fmt.Errorf("failed to parse transfer mapping: %w", err)
+	}
+	return &m, nil
+}
+
+// mappingComment is a trusted comment carrying a transfer mapping
+type mappingComment struct {
+	ID      int
+	Mapping *Mapping
+}
+
+// findMapping returns the newest trusted mapping comment among comments, or
+// nil when the issue was never transferred by simili
+func findMapping(ctx context.Context, comments []github.Comment, store *pending.CommentStore, secret string) *mappingComment {
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		if !mappingMetadataRegex.MatchString(c.Body) || !store.Trusted(ctx, c.User) {
+			continue
+		}
+		m, err := ParseMappingMetadata(c.Body, secret)
+		if err != nil {
+			continue
+		}
+		return &mappingComment{ID: c.ID, Mapping: m}
+	}
+	return nil
+}
+
+// FindMapping returns the transfer mapping carried by the comments of an
+// issue, or nil when simili never transferred it
+func FindMapping(ctx context.Context, gh *github.Client, cfg *config.Config, comments []github.Comment) *Mapping {
+	store := pending.NewCommentStore(gh, &cfg.Defaults.DelayedActions)
+	if found := findMapping(ctx, comments, store, cfg.Defaults.DelayedActions.SigningSecret); found != nil {
+		return found.Mapping
+	}
+	return nil
+}
+
+// formatMappingComment creates the comment posted on a moved issue, linking
+// back to where it came from
+func formatMappingComment(m *Mapping, offerRevert bool, cancelReaction, secret string) (string, error) {
+	metadata, err := FormatMappingMetadata(m, secret)
+	if err != nil {
+		return "", err
+	}
+
+	hop := m.Last()
+	from := fmt.Sprintf("%s#%d", hop.From.FullRepo(), hop.From.Number)
+	if hop.From.URL != "" {
+		from = fmt.Sprintf("[%s](%s)", from, hop.From.URL)
+	}
+
+	var b strings.Builder
+	if hop.Revert {
+		fmt.Fprintf(&b, "↩️ This issue was moved back here from %s.\n", from)
+	} else {
+		fmt.Fprintf(&b, "🚚 This issue was transferred here from %s.\n", from)
+		if offerRevert {
+			fmt.Fprintf(&b, "\n**Mistake?** React with 👎 (%s) to this comment to move it back.\n", cancelReaction)
+		}
+	}
+	fmt.Fprintf(&b, "\n%s\n\n---\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>", metadata)
+	return b.String(), nil
+}
@@ -0,0 +1,108 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestMappingMetadata(t *testing.T) {
+	var m *Mapping
+	m = m.extend(Hop{
+		From: IssueRef{Org: "org", Repo: "a", Number: 1, URL: "https://github.com/org/a/issues/1"},
+		To:   IssueRef{Org: "org", Repo: "b", Number: 7, URL: "https://github.com/org/b/issues/7", NodeID: "I_abc"},
+		At:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	signed, err := FormatMappingMetadata(m, "secret")
+	if err != nil {
+		t.Fatalf("FormatMappingMetadata() error = %v", err)
+	}
+	got, err := ParseMappingMetadata(signed, "secret")
+	if err != nil {
+		t.Fatalf("ParseMappingMetadata() error = %v", err)
+	}
+	if len(got.Hops) != 1 || got.Hops[0].To != m.Hops[0].To {
+		t.Errorf("ParseMappingMetadata() = %+v, want %+v", got, m)
+	}
+
+	tampered := strings.Replace(signed, `"repo":"a"`, `"repo":"evil"`, 1)
+	if _, err := ParseMappingMetadata(tampered, "secret"); !errors.Is(err, pending.ErrInvalidSignature) {
+		t.Errorf("ParseMappingMetadata() of tampered metadata error = %v, want %v", err, pending.ErrInvalidSignature)
+	}
+}
+
+func TestMappingChain(t *testing.T) {
+	var m *Mapping
+	if m.Visited("org/a") || m.IsAt("org", "a") || m.Last() != nil {
+		t.Error("nil mapping should have no hops")
+	}
+
+	m = m.extend(Hop{From: IssueRef{Org: "org", Repo: "a", Number: 1}, To: IssueRef{Org: "org", Repo: "b", Number: 7}})
+	m = m.extend(Hop{From: IssueRef{Org: "org", Repo: "b", Number: 7}, To: IssueRef{Org: "org", Repo: "a", Number: 9}, Revert: true})
+
+	if !m.IsAt("org", "A") || m.IsAt("org", "b") {
+		t.Error("IsAt() should follow the last hop")
+	}
+	if !m.Last().Revert || m.Last().To.Number != 9 {
+		t.Errorf("Last() = %+v, want the revert to org/a#9", m.Last())
+	}
+	if !m.Visited("org/b") || m.Visited("org/c") {
+		t.Error("Visited() should cover every repository in the chain")
+	}
+}
+
+func TestFindMapping(t *testing.T) {
+	cfg := &config.DelayedActionsConfig{BotLogins: []string{"simili-bot"}}
+	store := pending.NewCommentStore(nil, cfg)
+
+	older := &Mapping{Hops: []Hop{{From: IssueRef{Org: "org", Repo: "a"}, To: IssueRef{Org: "org", Repo: "b"}}}}
+	newer := older.extend(Hop{From: IssueRef{Org: "org", Repo: "b"}, To: IssueRef{Org: "org", Repo: "c"}})
+	forged := &Mapping{Hops: []Hop{{From: IssueRef{Org: "org", Repo: "evil"}, To: IssueRef{Org: "org", Repo: "c"}}}}
+
+	comment := func(id int, login string, m *Mapping) github.Comment {
+		body, err := FormatMappingMetadata(m, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return github.Comment{ID: id, Body: body, User: github.User{Login: login}}
+	}
+
+	comments := []github.Comment{
+		comment(1, "simili-bot", older),
+		comment(2, "simili-bot", newer),
+		comment(3, "someone", forged),
+	}
+
+	found := findMapping(context.Background(), comments, store, "")
+	if found == nil || found.ID != 2 || !found.Mapping.IsAt("org", "c") || len(found.Mapping.Hops) != 2 {
+		t.Errorf("findMapping() = %+v, want the newest bot comment", found)
+	}
+}
+
+func TestLatestSourceComment(t *testing.T) {
+	notice := func(id int, org, repo string) github.Comment {
+		return github.Comment{ID: id, Body: formatOptimisticTransferComment(&models.Issue{Org: org, Repo: repo}, "org/b", nil, "-1")}
+	}
+
+	comments := []github.Comment{
+		notice(1, "org", "a"),
+		notice(2, "org", "c"),
+		notice(3, "org", "a"),
+		{ID: 4, Body: "unrelated"},
+	}
+
+	if got := latestSourceComment(comments, "org", "a"); got != 3 {
+		t.Errorf("latestSourceComment() = %d, want 3", got)
+	}
+	if got := latestSourceComment(comments, "org", "x"); got != 0 {
+		t.Errorf("latestSourceComment() = %d, want 0", got)
+	}
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"regexp"
+	"strings"
 
+	"github.com/Kavirubc/gh-simili/internal/audit"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
@@ -19,15 +23,19 @@ var revertMetadataRegex = regexp.MustCompile(`(?s)` + revertMetadataPattern)
 
 // RevertManager handles reverting transfers
 type RevertManager struct {
-	gh  *github.Client
-	cfg *config.Config
+	gh         *github.Client
+	cfg        *config.Config
+	authorizer *pending.Authorizer
+	trust      *pending.CommentStore
 }
 
 // NewRevertManager creates a new revert manager
 func NewRevertManager(gh *github.Client, cfg *config.Config) *RevertManager {
 	return &RevertManager{
-		gh:  gh,
-		cfg: cfg,
+		gh:         gh,
+		cfg:        cfg,
+		authorizer: pending.NewAuthorizer(gh, &cfg.Defaults.DelayedActions),
+		trust:      pending.NewCommentStore(gh, &cfg.Defaults.DelayedActions),
 	}
 }
 
@@ -36,9 +44,13 @@ type RevertAction struct {
 	SourceOrg  string
 	SourceRepo string
 	CommentID  int
+	DecidedBy  []string  // users whose reactions asked for the revert
+	Original   *IssueRef // the closed original, when the issue is a copy
 }
 
-// CheckForRevert checks if an issue should be reverted based on comments and reactions
+// CheckForRevert checks if an issue should be reverted based on comments and
+// reactions. The transfer mapping decides where the issue goes back to; an
+// issue that was itself moved back is never reverted again.
 func (m *RevertManager) CheckForRevert(ctx context.Context, issue *models.Issue) (*RevertAction, error) {
 	if !m.cfg.Defaults.DelayedActions.Enabled || !m.cfg.Defaults.DelayedActions.OptimisticTransfers {
 		return nil, nil
@@ -49,28 +61,96 @@ func (m *RevertManager) CheckForRevert(ctx context.Context, issue *models.Issue)
 		return nil, fmt.Errorf("failed to list comments: %w", err)
 	}
 
-	for _, comment := range comments {
-		matches := revertMetadataRegex.FindStringSubmatch(comment.Body)
-		if len(matches) < 2 {
+	found := findMapping(ctx, comments, m.trust, m.cfg.Defaults.DelayedActions.SigningSecret)
+	if found == nil || !found.Mapping.IsAt(issue.Org, issue.Repo) {
+		// Transferred before mappings were recorded
+		return m.checkLegacyRevert(ctx, issue, comments)
+	}
+
+	last := found.Mapping.Last()
+	if last.Revert {
+		return nil, nil
+	}
+
+	// The revert can be asked for on the mapping comment or on the notice
+	// posted just before the move
+	commentIDs := []int{found.ID}
+	if id := latestSourceComment(comments, last.From.Org, last.From.Repo); id != 0 {
+		commentIDs = append(commentIDs, id)
+	}
+
+	for _, id := range commentIDs {
+		decision, err := m.authorizer.Decide(ctx, issue.Org, issue.Repo, issue.Number, id)
+		if err != nil {
 			continue
 		}
+		if decision.Outcome == pending.DecisionCancel {
+			action := &RevertAction{
+				SourceOrg:  last.From.Org,
+				SourceRepo: last.From.Repo,
+				CommentID:  id,
+				DecidedBy:  decision.DecidedBy,
+			}
+			if last.Copy {
+				action.Original = &last.From
+			}
+			return action, nil
+		}
+	}
+
+	return nil, nil
+}
 
-		var metadata TransferSourceMetadata
-		if err := json.Unmarshal([]byte(matches[1]), &metadata); err != nil {
+// latestSourceComment returns the ID of the newest transfer notice naming
+// org/repo as the source, or 0
+func latestSourceComment(comments []github.Comment, org, repo string) int {
+	for i := len(comments) - 1; i >= 0; i-- {
+		metadata, ok := parseSourceMetadata(comments[i].Body)
+		if ok && strings.EqualFold(metadata.Org, org) && strings.EqualFold(metadata.Repo, repo) {
+			return comments[i].ID
+		}
+	}
+	return 0
+}
+
+// parseSourceMetadata extracts the source of a transfer notice
+func parseSourceMetadata(body string) (TransferSourceMetadata, bool) {
+	var metadata TransferSourceMetadata
+	matches := revertMetadataRegex.FindStringSubmatch(body)
+	if len(matches) < 2 {
+		return metadata, false
+	}
+	if err := json.Unmarshal([]byte(matches[1]), &metadata); err != nil {
+		return metadata, false
+	}
+	return metadata, true
+}
+
+// checkLegacyRevert looks for a revert request on the transfer notices of
+// an issue without a mapping. Only notices simili posted count; anyone else
+// could name any repository as the source.
+func (m *RevertManager) checkLegacyRevert(ctx context.Context, issue *models.Issue, comments []github.Comment) (*RevertAction, error) {
+	for _, comment := range comments {
+		metadata, ok := parseSourceMetadata(comment.Body)
+		if !ok || (strings.EqualFold(metadata.Org, issue.Org) && strings.EqualFold(metadata.Repo, issue.Repo)) {
+			continue
+		}
+		if !m.trust.Trusted(ctx, comment.User) {
 			continue
 		}
 
-		// Check for cancel reaction (which triggers revert in this context)
-		hasRevert, err := m.gh.HasReaction(ctx, issue.Org, issue.Repo, comment.ID, m.cfg.Defaults.DelayedActions.CancelReaction)
+		// Check for an authorized cancel reaction (which triggers revert in this context)
+		decision, err := m.authorizer.Decide(ctx, issue.Org, issue.Repo, issue.Number, comment.ID)
 		if err != nil {
 			continue
 		}
 
-		if hasRevert {
+		if decision.Outcome == pending.DecisionCancel {
 			return &RevertAction{
 				SourceOrg:  metadata.Org,
 				SourceRepo: metadata.Repo,
 				CommentID:  comment.ID,
+				DecidedBy:  decision.DecidedBy,
 			}, nil
 		}
 	}
@@ -78,21 +158,45 @@ func (m *RevertManager) CheckForRevert(ctx context.Context, issue *models.Issue)
 	return nil, nil
 }
 
-// Revert executes the revert transfer
-// Note: We reuse Executor logic but we need to handle the "reverse" transfer
+// Revert moves the issue back to where it came from and records the move
+// as a revert in its mapping
 func (m *RevertManager) Revert(ctx context.Context, issue *models.Issue, action *RevertAction, executor *Executor) error {
 	targetRepo := fmt.Sprintf("%s/%s", action.SourceOrg, action.SourceRepo)
 
-	// Post revert comment
-	revertMsg := fmt.Sprintf("↩️ Reverting transfer. Moving issue back to **%s** based on user request.", targetRepo)
-	if err := m.gh.PostComment(ctx, issue.Org, issue.Repo, issue.Number, revertMsg); err != nil {
-		return fmt.Errorf("failed to post revert comment: %w", err)
+	// Like a pending transfer, a revert may only move the issue to a
+	// configured repository
+	check := &pending.PendingAction{Type: pending.ActionTypeTransfer, Org: issue.Org, Repo: issue.Repo, Target: targetRepo}
+	if err := pending.ValidateTarget(m.cfg, check); err != nil {
+		return fmt.Errorf("refusing to revert: %w", err)
+	}
+
+	if executor.dryRun {
+		log.Printf("[DRY RUN] Would move #%d back to %s", issue.Number, targetRepo)
+		return nil
 	}
 
-	// Execute transfer using the executor (which handles auth/client)
-	// We pass nil rule as this is manual revert
-	// We construct a temporary issue object for the executor
-	if err := executor.executeTransfer(ctx, issue, targetRepo, nil); err != nil {
+	revertMsg := fmt.Sprintf("↩️ Reverting transfer. Moving issue back to **%s** based on user request.", targetRepo)
+	var (
+		moved *github.TransferredIssue
+		err   error
+	)
+	if action.Original != nil {
+		// A copy goes back by reopening the original
+		moved, err = executor.revertCopy(ctx, issue, *action.Original, revertMsg)
+	} else {
+		moved, err = executor.transfer(ctx, issue, targetRepo, nil, revertMsg, true)
+	}
+	executor.audit.Record(audit.Entry{
+		Org:     issue.Org,
+		Repo:    issue.Repo,
+		Issue:   issue.Number,
+		Action:  "revert",
+		Target:  movedTarget(targetRepo, moved),
+		Actor:   audit.Actor(action.DecidedBy),
+		Outcome: audit.OutcomeOf(err, audit.OutcomeReverted),
+		Error:   audit.ErrorText(err),
+	})
+	if err != nil {
 		return fmt.Errorf("failed to execute revert transfer: %w", err)
 	}
 
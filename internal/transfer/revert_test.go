@@ -0,0 +1,72 @@
+package transfer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// authorThumbsDown reports a 👎 from the issue author on every comment
+type authorThumbsDown struct{}
+
+func (authorThumbsDown) GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error) {
+	return &models.Issue{Org: org, Repo: repo, Number: number, Author: "reporter"}, nil
+}
+
+func (authorThumbsDown) ListCommentReactions(ctx context.Context, org, repo string, commentID int) ([]github.Reaction, error) {
+	return []github.Reaction{{Content: "-1", User: github.User{Login: "reporter"}}}, nil
+}
+
+func (authorThumbsDown) CollaboratorPermission(ctx context.Context, org, repo, user string) (string, error) {
+	return "none", nil
+}
+
+func (authorThumbsDown) IsTeamMember(ctx context.Context, org, team, user string) (bool, error) {
+	return false, nil
+}
+
+func TestCheckLegacyRevert(t *testing.T) {
+	cfg := &config.Config{Defaults: config.DefaultsConfig{DelayedActions: config.DelayedActionsConfig{
+		CancelReaction: "-1",
+		BotLogins:      []string{"simili-bot"},
+		Authorization:  config.ReactionAuthConfig{Roles: []string{"author"}, Quorum: 1},
+	}}}
+	m := &RevertManager{
+		cfg:        cfg,
+		authorizer: pending.NewAuthorizer(authorThumbsDown{}, &cfg.Defaults.DelayedActions),
+		trust:      pending.NewCommentStore(nil, &cfg.Defaults.DelayedActions),
+	}
+	issue := &models.Issue{Org: "org", Repo: "b", Number: 7}
+	notice := func(id int, login, sourceRepo string) github.Comment {
+		body := formatOptimisticTransferComment(&models.Issue{Org: "org", Repo: sourceRepo}, "org/b", nil, "-1")
+		return github.Comment{ID: id, Body: body, User: github.User{Login: login}}
+	}
+
+	forged := []github.Comment{notice(1, "reporter", "private-repo")}
+	action, err := m.checkLegacyRevert(context.Background(), issue, forged)
+	if err != nil || action != nil {
+		t.Errorf("checkLegacyRevert() with a forged notice = %+v, %v; want nil", action, err)
+	}
+
+	genuine := append(forged, notice(2, "simili-bot", "a"))
+	action, err = m.checkLegacyRevert(context.Background(), issue, genuine)
+	if err != nil || action == nil || action.SourceRepo != "a" || action.CommentID != 2 {
+		t.Errorf("checkLegacyRevert() = %+v, %v; want a revert to org/a from comment 2", action, err)
+	}
+}
+
+func TestRevertRequiresConfiguredTarget(t *testing.T) {
+	cfg := &config.Config{Repositories: []config.RepositoryConfig{{Org: "org", Repo: "a"}, {Org: "org", Repo: "b"}}}
+	m := &RevertManager{cfg: cfg}
+
+	issue := &models.Issue{Org: "org", Repo: "b", Number: 7}
+	err := m.Revert(context.Background(), issue, &RevertAction{SourceOrg: "org", SourceRepo: "private-repo"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "not a configured repository") {
+		t.Errorf("Revert() to an unconfigured repository error = %v", err)
+	}
+}
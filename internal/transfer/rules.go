@@ -1,16 +1,32 @@
 package transfer
 
 import (
+	"fmt"
+	"log"
+	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/match"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
 // RuleMatcher evaluates transfer rules against issues
 type RuleMatcher struct {
-	rules []config.TransferRule
+	rules      []config.TransferRule
+	conditions []*condition // compiled match conditions, parallel to rules
+}
+
+// condition is a compiled MatchCondition
+type condition struct {
+	cond     *config.MatchCondition
+	titleRe  *regexp.Regexp
+	bodyRe   *regexp.Regexp
+	keywords *match.Set
+	not      *condition
+	anyOf    []*condition
+	allOf    []*condition
 }
 
 // NewRuleMatcher creates a matcher for a repository's transfer rules
@@ -22,25 +38,80 @@ func NewRuleMatcher(rules []config.TransferRule) *RuleMatcher {
 		return sorted[i].Priority < sorted[j].Priority
 	})
 
-	return &RuleMatcher{rules: sorted}
+	conditions := make([]*condition, len(sorted))
+	for i := range sorted {
+		c, err := compileCondition(&sorted[i].Match)
+		if err != nil {
+			// Leave the condition nil so the rule never matches
+			log.Printf("Warning: invalid transfer rule for %s: %v", sorted[i].Target, err)
+			continue
+		}
+		conditions[i] = c
+	}
+
+	return &RuleMatcher{rules: sorted, conditions: conditions}
+}
+
+// compileCondition compiles the patterns of a condition and its nested conditions
+func compileCondition(cond *config.MatchCondition) (*condition, error) {
+	c := &condition{cond: cond}
+	var err error
+
+	if cond.TitleRegex != "" {
+		if c.titleRe, err = regexp.Compile("(?i)" + cond.TitleRegex); err != nil {
+			return nil, fmt.Errorf("invalid title_regex: %w", err)
+		}
+	}
+	if cond.BodyRegex != "" {
+		if c.bodyRe, err = regexp.Compile("(?i)" + cond.BodyRegex); err != nil {
+			return nil, fmt.Errorf("invalid body_regex: %w", err)
+		}
+	}
+	if len(cond.Keywords) > 0 {
+		if c.keywords, err = match.Compile(cond.Keywords); err != nil {
+			return nil, err
+		}
+	}
+
+	if cond.Not != nil {
+		if c.not, err = compileCondition(cond.Not); err != nil {
+			return nil, err
+		}
+	}
+	for i := range cond.AnyOf {
+		sub, err := compileCondition(&cond.AnyOf[i])
+		if err != nil {
+			return nil, err
+		}
+		c.anyOf = append(c.anyOf, sub)
+	}
+	for i := range cond.AllOf {
+		sub, err := compileCondition(&cond.AllOf[i])
+		if err != nil {
+			return nil, err
+		}
+		c.allOf = append(c.allOf, sub)
+	}
+
+	return c, nil
 }
 
 // Match finds the first matching rule for an issue
 // Returns target repo and the matched rule, or empty string if no match
 func (m *RuleMatcher) Match(issue *models.Issue) (string, *config.TransferRule) {
 	for i := range m.rules {
-		if m.matchesRule(issue, &m.rules[i]) {
+		if m.conditions[i] != nil && m.matches(issue, m.conditions[i]) {
 			return m.rules[i].Target, &m.rules[i]
 		}
 	}
 	return "", nil
 }
 
-// matchesRule checks if an issue matches a single rule
+// matches checks if an issue matches a condition
 // Multiple conditions in same rule = AND logic
 // Multiple values in same condition = OR logic
-func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule) bool {
-	cond := &rule.Match
+func (m *RuleMatcher) matches(issue *models.Issue, c *condition) bool {
+	cond := c.cond
 	matchCount := 0
 	condCount := 0
 
@@ -68,6 +139,20 @@ func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule
 		}
 	}
 
+	// Check title and body regexes
+	if c.titleRe != nil {
+		condCount++
+		if c.titleRe.MatchString(issue.Title) {
+			matchCount++
+		}
+	}
+	if c.bodyRe != nil {
+		condCount++
+		if c.bodyRe.MatchString(issue.Body) {
+			matchCount++
+		}
+	}
+
 	// Check author (exact match)
 	if cond.Author != "" {
 		condCount++
@@ -76,10 +161,109 @@ func (m *RuleMatcher) matchesRule(issue *models.Issue, rule *config.TransferRule
 		}
 	}
 
+	// Check author association (OR logic within)
+	if len(cond.AuthorAssociation) > 0 {
+		condCount++
+		if m.equalsAny(issue.AuthorAssociation, cond.AuthorAssociation) {
+			matchCount++
+		}
+	}
+
+	// Check issue type (OR logic within)
+	if len(cond.IssueType) > 0 {
+		condCount++
+		if m.equalsAny(issue.Type, cond.IssueType) {
+			matchCount++
+		}
+	}
+
+	// Check issue form fields (every field must have one of its values)
+	if len(cond.Fields) > 0 {
+		condCount++
+		if m.matchesFields(issue.Body, cond.Fields) {
+			matchCount++
+		}
+	}
+
+	// Check weighted keyword rules
+	if c.keywords != nil {
+		condCount++
+		if score, _ := c.keywords.Score(issue); score > 0 && score >= cond.KeywordScore {
+			matchCount++
+		}
+	}
+
+	// Check nested conditions
+	if c.not != nil {
+		condCount++
+		if !m.matches(issue, c.not) {
+			matchCount++
+		}
+	}
+	if len(c.anyOf) > 0 {
+		condCount++
+		for _, sub := range c.anyOf {
+			if m.matches(issue, sub) {
+				matchCount++
+				break
+			}
+		}
+	}
+	if len(c.allOf) > 0 {
+		condCount++
+		all := true
+		for _, sub := range c.allOf {
+			if !m.matches(issue, sub) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matchCount++
+		}
+	}
+
 	// AND logic: all conditions must match
 	return condCount > 0 && matchCount == condCount
 }
 
+// equalsAny checks if value equals any of the candidates (case-insensitive)
+func (m *RuleMatcher) equalsAny(value string, candidates []string) bool {
+	if value == "" {
+		return false
+	}
+	for _, c := range candidates {
+		if strings.EqualFold(value, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesFields checks issue form answers. A field matches when its answer,
+// or one entry of a comma-separated multi-select answer, equals an accepted value.
+func (m *RuleMatcher) matchesFields(body string, fields map[string][]string) bool {
+	answers := match.FormFields(body)
+	for label, accepted := range fields {
+		answer, ok := answers[strings.ToLower(label)]
+		if !ok {
+			return false
+		}
+
+		found := m.equalsAny(answer, accepted)
+		for _, part := range strings.Split(answer, ",") {
+			if found {
+				break
+			}
+			found = m.equalsAny(strings.TrimSpace(part), accepted)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // matchesAnyLabel checks if any issue label matches any rule label
 func (m *RuleMatcher) matchesAnyLabel(issueLabels, ruleLabels []string) bool {
 	for _, il := range issueLabels {
@@ -176,3 +176,135 @@ func TestRuleMatcher_Match_ANDLogic(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleMatcher_Match_Keywords(t *testing.T) {
+	rules := []config.TransferRule{
+		{
+			Match: config.MatchCondition{
+				Keywords: []config.KeywordRule{
+					{Pattern: "docs", WholeWord: true, Weight: 0.6},
+					{Pattern: `\breadme\b`, Regex: true, Field: "title", Weight: 0.6},
+					{Pattern: "crash", Weight: -1},
+				},
+				KeywordScore: 0.7,
+			},
+			Target:   "org/docs",
+			Priority: 1,
+		},
+	}
+
+	matcher := NewRuleMatcher(rules)
+
+	tests := []struct {
+		name      string
+		issue     *models.Issue
+		wantMatch bool
+	}{
+		{
+			name:      "enough keyword evidence",
+			issue:     &models.Issue{Title: "README typo", Body: "The docs are wrong"},
+			wantMatch: true,
+		},
+		{
+			name:      "below keyword score",
+			issue:     &models.Issue{Title: "Typo", Body: "The docs are wrong"},
+			wantMatch: false,
+		},
+		{
+			name:      "negative keyword",
+			issue:     &models.Issue{Title: "README crash", Body: "The docs crash"},
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, _ := matcher.Match(tt.issue)
+			gotMatch := target != ""
+			if gotMatch != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", gotMatch, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestRuleMatcher_Match_Nested(t *testing.T) {
+	rules := []config.TransferRule{
+		{
+			Match: config.MatchCondition{
+				TitleRegex: `^\[(docs|website)\]`,
+				Not:        &config.MatchCondition{AuthorAssociation: []string{"MEMBER", "OWNER"}},
+				AnyOf: []config.MatchCondition{
+					{IssueType: []string{"Task"}},
+					{Fields: map[string][]string{"Area": {"Documentation"}}},
+				},
+			},
+			Target:   "org/docs",
+			Priority: 1,
+		},
+	}
+
+	matcher := NewRuleMatcher(rules)
+
+	formBody := "### Area\n\nDocumentation, CLI\n\n### Description\n\n_No response_"
+
+	tests := []struct {
+		name      string
+		issue     *models.Issue
+		wantMatch bool
+	}{
+		{
+			name:      "issue type",
+			issue:     &models.Issue{Title: "[docs] Fix typo", AuthorAssociation: "FIRST_TIME_CONTRIBUTOR", Type: "task"},
+			wantMatch: true,
+		},
+		{
+			name:      "form field",
+			issue:     &models.Issue{Title: "[website] Broken link", AuthorAssociation: "NONE", Body: formBody},
+			wantMatch: true,
+		},
+		{
+			name:      "excluded association",
+			issue:     &models.Issue{Title: "[docs] Fix typo", AuthorAssociation: "MEMBER", Type: "Task"},
+			wantMatch: false,
+		},
+		{
+			name:      "title regex mismatch",
+			issue:     &models.Issue{Title: "Fix typo [docs]", Type: "Task"},
+			wantMatch: false,
+		},
+		{
+			name:      "no any_of branch",
+			issue:     &models.Issue{Title: "[docs] Fix typo", Type: "Bug"},
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target, _ := matcher.Match(tt.issue)
+			gotMatch := target != ""
+			if gotMatch != tt.wantMatch {
+				t.Errorf("Match() = %v, want %v", gotMatch, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestFormatMatchDescription_Nested(t *testing.T) {
+	rule := &config.TransferRule{
+		Match: config.MatchCondition{
+			Labels: []string{"docs"},
+			Not:    &config.MatchCondition{AuthorAssociation: []string{"MEMBER"}},
+			AnyOf: []config.MatchCondition{
+				{TitleRegex: "^docs"},
+				{IssueType: []string{"Task"}},
+			},
+		},
+	}
+
+	want := "`labels: [docs]` + not (`author_association: [MEMBER]`) + any of (`title_regex: /^docs/` | `issue_type: [Task]`)"
+	if got := formatMatchDescription(rule); got != want {
+		t.Errorf("formatMatchDescription() = %q, want %q", got, want)
+	}
+}
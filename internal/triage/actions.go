@@ -7,8 +7,10 @@ import (
 	"log"
 	"os"
 
+	"github.com/Kavirubc/gh-simili/internal/audit"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
@@ -18,13 +20,18 @@ type Executor struct {
 	dryRun        bool
 	cfg           *config.Config
 	duplicateChecker *DuplicateChecker
+	pendingManager   *pending.Manager
+	audit            *audit.Log
 }
 
-// NewExecutor creates a new action executor
-func NewExecutor(client *github.Client, dryRun bool) *Executor {
+// NewExecutor creates a new action executor. cfg may be nil, in which case
+// actions run with default settings and are not audited.
+func NewExecutor(client *github.Client, cfg *config.Config, dryRun bool) *Executor {
 	return &Executor{
 		client: client,
 		dryRun: dryRun,
+		cfg:    cfg,
+		audit:  audit.New(cfg),
 	}
 }
 
@@ -35,18 +42,32 @@ func NewExecutorWithDelayedActions(client *github.Client, cfg *config.Config, du
 		dryRun:           dryRun,
 		cfg:              cfg,
 		duplicateChecker: duplicateChecker,
+		pendingManager:   pending.NewManager(client, cfg),
+		audit:            audit.New(cfg),
 	}
 }
 
-// Execute performs all actions in a triage result
+// Execute performs all actions in a triage result. Actions configured as
+// delayed are scheduled together instead.
 func (e *Executor) Execute(ctx context.Context, issue *models.Issue, result *Result) error {
-	for _, action := range result.Actions {
+	e.run(ctx, issue, result.Actions, result)
+	return nil
+}
+
+// run executes actions now and schedules the delayed ones
+func (e *Executor) run(ctx context.Context, issue *models.Issue, actions []Action, result *Result) {
+	now, later := e.splitDelayed(actions)
+	for _, action := range now {
 		if err := e.executeAction(ctx, issue, action, result); err != nil {
 			log.Printf("Error executing action %s: %v", action.Type, err)
 			// Continue with other actions
 		}
 	}
-	return nil
+	if len(later) > 0 {
+		if err := e.ScheduleActions(ctx, issue, later); err != nil {
+			log.Printf("Error scheduling delayed actions: %v", err)
+		}
+	}
 }
 
 
@@ -59,15 +80,47 @@ func (e *Executor) executeAction(ctx context.Context, issue *models.Issue, actio
 		return nil
 	}
 
+	scheduled, err := e.apply(ctx, issue, action, result)
+	if !scheduled {
+		e.audit.Record(audit.Entry{
+			Org:        issue.Org,
+			Repo:       issue.Repo,
+			Issue:      issue.Number,
+			Action:     string(action.Type),
+			Target:     actionTarget(action),
+			Rule:       action.Reason,
+			Confidence: actionConfidence(action, result),
+			Outcome:    audit.OutcomeOf(err, audit.OutcomeExecuted),
+			Error:      audit.ErrorText(err),
+		})
+	}
+	return err
+}
+
+// apply performs a single action. scheduled is true when a close was turned
+// into a pending action, which is audited by the pending manager instead.
+func (e *Executor) apply(ctx context.Context, issue *models.Issue, action Action, result *Result) (scheduled bool, err error) {
 	switch action.Type {
 	case ActionAddLabel:
-		return e.client.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{action.Label})
+		if err := e.client.AddLabels(ctx, issue.Org, issue.Repo, issue.Number, []string{action.Label}); err != nil {
+			return false, err
+		}
+		if e.cfg != nil && action.Label == e.cfg.Triage.Quality.NeedsInfoLabel {
+			return false, e.ScheduleNeedsInfoClose(ctx, issue)
+		}
+		return false, nil
 
 	case ActionRemoveLabel:
-		return e.client.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, action.Label)
+		return false, e.client.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, action.Label)
 
 	case ActionComment:
-		return e.client.PostComment(ctx, issue.Org, issue.Repo, issue.Number, action.Comment)
+		return false, e.client.PostComment(ctx, issue.Org, issue.Repo, issue.Number, action.Comment)
+
+	case ActionLock:
+		return false, e.client.LockIssue(ctx, issue.Org, issue.Repo, issue.Number)
+
+	case ActionAssign:
+		return false, e.client.AddAssignees(ctx, issue.Org, issue.Repo, issue.Number, []string{action.Assignee})
 
 	case ActionClose:
 		// Check if delayed actions are enabled - if so, schedule instead of closing immediately
@@ -75,15 +128,56 @@ func (e *Executor) executeAction(ctx context.Context, issue *models.Issue, actio
 			// Check if this is a duplicate close action
 			if dupResult := result.Duplicate; dupResult != nil && dupResult.IsDuplicate {
 				// Schedule delayed close instead of closing immediately
-				return e.duplicateChecker.ScheduleClose(ctx, issue, dupResult)
+				return true, e.duplicateChecker.ScheduleClose(ctx, issue, dupResult)
 			}
 		}
 		// Fall back to immediate close if delayed actions not enabled or not a duplicate
-		return e.client.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, "not_planned")
+		if dupResult := result.Duplicate; dupResult != nil && dupResult.IsDuplicate && dupResult.Original != nil {
+			return false, CloseAsDuplicate(ctx, e.client, e.config(), issue, dupResult.Original.URL)
+		}
+		return false, e.client.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, github.CloseReasonNotPlanned)
 
 	default:
-		return fmt.Errorf("unknown action type: %s", action.Type)
+		return false, fmt.Errorf("unknown action type: %s", action.Type)
+	}
+}
+
+// actionTarget returns the label or assignee an action refers to
+func actionTarget(action Action) string {
+	switch action.Type {
+	case ActionAddLabel, ActionRemoveLabel:
+		return action.Label
+	case ActionAssign:
+		return action.Assignee
+	}
+	return ""
+}
+
+// actionConfidence returns the confidence behind an action, when the triage
+// result has one
+func actionConfidence(action Action, result *Result) float64 {
+	if result == nil {
+		return 0
+	}
+	if action.Type == ActionAddLabel {
+		for _, l := range result.Labels {
+			if l.Label == action.Label {
+				return l.Confidence
+			}
+		}
+	}
+	if action.Type == ActionClose && result.Duplicate != nil {
+		return result.Duplicate.Similarity
+	}
+	return 0
+}
+
+// config returns the executor's config, or an empty one when it has none
+func (e *Executor) config() *config.Config {
+	if e.cfg == nil {
+		return &config.Config{}
 	}
+	return e.cfg
 }
 
 // ExecuteSelective executes only specific action types
@@ -93,14 +187,13 @@ func (e *Executor) ExecuteSelective(ctx context.Context, issue *models.Issue, re
 		allowed[t] = true
 	}
 
+	var selected []Action
 	for _, action := range result.Actions {
-		if !allowed[action.Type] {
-			continue
-		}
-		if err := e.executeAction(ctx, issue, action, result); err != nil {
-			log.Printf("Error executing action %s: %v", action.Type, err)
+		if allowed[action.Type] {
+			selected = append(selected, action)
 		}
 	}
+	e.run(ctx, issue, selected, result)
 	return nil
 }
 
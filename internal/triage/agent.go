@@ -6,6 +6,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/Kavirubc/gh-simili/internal/cluster"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/llm"
@@ -22,31 +23,49 @@ type Agent struct {
 	classifier *Classifier
 	quality    *QualityChecker
 	duplicate  *DuplicateChecker
+	verifier   *DuplicateVerifier
 	similarity *processor.SimilarityFinder
 }
 
 // NewAgent creates a new triage agent
 func NewAgent(cfg *config.Config, llmProvider llm.Provider, similarity *processor.SimilarityFinder) *Agent {
-	return &Agent{
+	a := &Agent{
 		cfg:        cfg,
 		llm:        llmProvider,
-		classifier: NewClassifier(llmProvider, &cfg.Triage.Classifier),
+		classifier: newClassifier(cfg, llmProvider, similarity),
 		quality:    NewQualityChecker(llmProvider, &cfg.Triage.Quality),
 		duplicate:  NewDuplicateChecker(&cfg.Triage.Duplicate),
 		similarity: similarity,
 	}
+	if cfg.Triage.Duplicate.LLMVerify {
+		a.verifier = NewDuplicateVerifier(llmProvider, nil)
+	}
+	if cfg.Clustering.Enabled && similarity != nil {
+		a.duplicate.clusters = cluster.NewManager(similarity.VectorDB(), &cfg.Clustering)
+	}
+	return a
 }
 
 // NewAgentWithGitHub creates a new triage agent with GitHub client for delayed actions
 func NewAgentWithGitHub(cfg *config.Config, llmProvider llm.Provider, similarity *processor.SimilarityFinder, gh *github.Client) *Agent {
-	return &Agent{
+	a := &Agent{
 		cfg:        cfg,
 		llm:        llmProvider,
-		classifier: NewClassifier(llmProvider, &cfg.Triage.Classifier),
+		classifier: newClassifier(cfg, llmProvider, similarity),
 		quality:    NewQualityChecker(llmProvider, &cfg.Triage.Quality),
 		duplicate:  NewDuplicateCheckerWithDelayedActions(&cfg.Triage.Duplicate, gh, cfg),
 		similarity: similarity,
 	}
+	if cfg.Triage.Duplicate.LLMVerify {
+		a.verifier = NewDuplicateVerifier(llmProvider, gh)
+	}
+	if cfg.Triage.Classifier.SyncLabels {
+		a.classifier.SyncLabels(gh)
+	}
+	if cfg.Clustering.Enabled && similarity != nil {
+		a.duplicate.clusters = cluster.NewManager(similarity.VectorDB(), &cfg.Clustering)
+	}
+	return a
 }
 
 // Triage performs full triage analysis on an issue
@@ -76,7 +95,7 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 
 	// Step 2: Check for duplicates (only if no transfer rule matched)
 	if !shouldSkipDuplicateCheck && a.cfg.Triage.Duplicate.Enabled && len(similarIssues) > 0 {
-		dupResult := a.duplicate.Check(similarIssues)
+		dupResult := a.checkDuplicate(ctx, issue, similarIssues)
 		result.Duplicate = dupResult
 
 		if dupResult.IsDuplicate {
@@ -95,7 +114,7 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 			log.Printf("Warning: label classification failed: %v", err)
 		} else {
 			result.Labels = labels
-			result.Actions = append(result.Actions, a.labelsToActions(labels)...)
+			result.Actions = append(result.Actions, a.labelsToActions(issue, labels)...)
 		}
 	}
 
@@ -123,9 +142,56 @@ func (a *Agent) Triage(ctx context.Context, issue *models.Issue) (*Result, error
 	return result, nil
 }
 
-// labelsToActions converts label results to actions
-func (a *Agent) labelsToActions(labels []LabelResult) []Action {
+// ClassifyLabels returns the labels the classifier adds to an issue, and
+// the ones they conflict with, using the labels of the repository the issue
+// is in. Transfers use it to triage an issue when it arrives.
+func (a *Agent) ClassifyLabels(ctx context.Context, issue *models.Issue) (add, remove []string, err error) {
+	labels, err := a.classifier.Classify(ctx, issue)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, action := range a.labelsToActions(issue, labels) {
+		switch action.Type {
+		case ActionAddLabel:
+			add = append(add, action.Label)
+		case ActionRemoveLabel:
+			remove = append(remove, action.Label)
+		}
+	}
+	return add, remove, nil
+}
+
+// newClassifier creates the classifier, using the similarity finder as the
+// source of labeled examples when one is available
+func newClassifier(cfg *config.Config, llmProvider llm.Provider, similarity *processor.SimilarityFinder) *Classifier {
+	if similarity == nil {
+		return NewClassifier(llmProvider, &cfg.Triage.Classifier)
+	}
+	return NewClassifierWithExamples(llmProvider, &cfg.Triage.Classifier, similarity)
+}
+
+// checkDuplicate runs similarity-based duplicate detection, redirects the
+// match to its cluster's canonical issue, then verifies it with the LLM when enabled
+func (a *Agent) checkDuplicate(ctx context.Context, issue *models.Issue, similarIssues []vectordb.SearchResult) *DuplicateResult {
+	dupResult := a.duplicate.Check(similarIssues)
+	a.duplicate.PointToCanonical(ctx, dupResult)
+	if a.verifier != nil && dupResult.IsDuplicate {
+		a.verifier.Verify(ctx, issue, dupResult)
+	}
+	return dupResult
+}
+
+// labelsToActions converts label results to actions, removing labels already
+// on the issue that conflict with a suggestion in an exclusive group
+func (a *Agent) labelsToActions(issue *models.Issue, labels []LabelResult) []Action {
 	var actions []Action
+	for _, l := range a.classifier.Conflicts(issue.Labels, labels) {
+		actions = append(actions, Action{
+			Type:   ActionRemoveLabel,
+			Label:  l,
+			Reason: "conflicts with a suggested label in the same exclusive group",
+		})
+	}
 	for _, l := range labels {
 		actions = append(actions, Action{
 			Type:   ActionAddLabel,
@@ -206,6 +272,9 @@ func (a *Agent) buildSummaryComment(result *Result, similarIssues []vectordb.Sea
 		if result.Duplicate.Original != nil {
 			dupLine += fmt.Sprintf("\nOriginal: #%d - %s", result.Duplicate.Original.Number, result.Duplicate.Original.Title)
 		}
+		if result.Duplicate.Rationale != "" {
+			dupLine += fmt.Sprintf("\nRationale: %s", result.Duplicate.Rationale)
+		}
 		sections = append(sections, dupLine)
 	}
 
@@ -223,7 +292,7 @@ func (a *Agent) TriageWithSimilar(ctx context.Context, issue *models.Issue, simi
 
 	// Check for duplicates
 	if a.cfg.Triage.Duplicate.Enabled && len(similarIssues) > 0 {
-		dupResult := a.duplicate.Check(similarIssues)
+		dupResult := a.checkDuplicate(ctx, issue, similarIssues)
 		result.Duplicate = dupResult
 
 		if dupResult.IsDuplicate {
@@ -241,7 +310,7 @@ func (a *Agent) TriageWithSimilar(ctx context.Context, issue *models.Issue, simi
 			log.Printf("Warning: label classification failed: %v", err)
 		} else {
 			result.Labels = labels
-			result.Actions = append(result.Actions, a.labelsToActions(labels)...)
+			result.Actions = append(result.Actions, a.labelsToActions(issue, labels)...)
 		}
 	}
 
@@ -277,7 +346,7 @@ func (a *Agent) TriageWithoutDuplicates(ctx context.Context, issue *models.Issue
 			log.Printf("Warning: label classification failed: %v", err)
 		} else {
 			result.Labels = labels
-			result.Actions = append(result.Actions, a.labelsToActions(labels)...)
+			result.Actions = append(result.Actions, a.labelsToActions(issue, labels)...)
 		}
 	}
 
@@ -0,0 +1,154 @@
+package triage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// duplicatesMetadataPattern extracts the duplicate list from a back-link comment
+var duplicatesMetadataPattern = regexp.MustCompile(`<!-- simili-duplicates: (\[.*?\]) -->`)
+
+// duplicateRef identifies an issue closed as a duplicate
+type duplicateRef struct {
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// duplicateCloser is the subset of github.Client used to close duplicates
+type duplicateCloser interface {
+	CloseIssue(ctx context.Context, org, repo string, number int, reason string) error
+	CloseAsDuplicate(ctx context.Context, org, repo string, number int, originalOrg, originalRepo string, originalNumber int) error
+	ListComments(ctx context.Context, org, repo string, number int) ([]github.Comment, error)
+	UpdateComment(ctx context.Context, org, repo string, commentID int, body string) error
+	PostComment(ctx context.Context, org, repo string, number int, body string) error
+}
+
+// commentTrust decides whether a comment was written by the bot
+type commentTrust interface {
+	Trusted(ctx context.Context, user github.User) bool
+}
+
+// CloseAsDuplicate closes issue as a duplicate of the issue at originalURL
+// using GitHub's native duplicate relationship, then updates the back-link
+// comment on the original and mentions the author there when configured.
+func CloseAsDuplicate(ctx context.Context, gh *github.Client, cfg *config.Config, issue *models.Issue, originalURL string) error {
+	trust := pending.NewCommentStore(gh, &cfg.Defaults.DelayedActions)
+	return closeAsDuplicate(ctx, gh, trust, &cfg.Triage.Duplicate, issue, originalURL)
+}
+
+// closeAsDuplicate implements CloseAsDuplicate
+func closeAsDuplicate(ctx context.Context, gh duplicateCloser, trust commentTrust, cfg *config.DuplicateConfig, issue *models.Issue, originalURL string) error {
+	org, repo, number, err := github.ParseIssueURL(originalURL)
+	if err != nil {
+		log.Printf("Warning: %v; closing without duplicate link", err)
+		return gh.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, github.CloseReasonDuplicate)
+	}
+
+	if err := gh.CloseAsDuplicate(ctx, issue.Org, issue.Repo, issue.Number, org, repo, number); err != nil {
+		return err
+	}
+
+	if cfg.BackLink {
+		if err := updateBackLink(ctx, gh, trust, org, repo, number, issue); err != nil {
+			log.Printf("Warning: failed to update back-link on %s/%s#%d: %v", org, repo, number, err)
+		}
+	}
+
+	if cfg.MentionAuthor && issue.Author != "" {
+		if err := gh.PostComment(ctx, org, repo, number, formatAuthorMention(issue)); err != nil {
+			log.Printf("Warning: failed to mention author on %s/%s#%d: %v", org, repo, number, err)
+		}
+	}
+
+	return nil
+}
+
+// updateBackLink adds issue to the duplicate list comment on the original,
+// creating the comment on first use. Only the bot's own comment is reused:
+// anyone can post the marker, and their list must not be rewritten.
+func updateBackLink(ctx context.Context, gh duplicateCloser, trust commentTrust, org, repo string, number int, issue *models.Issue) error {
+	comments, err := gh.ListComments(ctx, org, repo, number)
+	if err != nil {
+		return err
+	}
+
+	ref := duplicateRef{Repo: issue.FullRepo(), Number: issue.Number, Title: issue.Title}
+
+	for _, c := range comments {
+		refs, ok := parseDuplicateRefs(c.Body)
+		if !ok || !trust.Trusted(ctx, c.User) {
+			continue
+		}
+		for _, r := range refs {
+			if r.Repo == ref.Repo && r.Number == ref.Number {
+				return nil // Already listed
+			}
+		}
+		body, err := formatBackLinkComment(append(refs, ref))
+		if err != nil {
+			return err
+		}
+		return gh.UpdateComment(ctx, org, repo, c.ID, body)
+	}
+
+	body, err := formatBackLinkComment([]duplicateRef{ref})
+	if err != nil {
+		return err
+	}
+	return gh.PostComment(ctx, org, repo, number, body)
+}
+
+// parseDuplicateRefs reads the duplicate list from a back-link comment
+func parseDuplicateRefs(body string) ([]duplicateRef, bool) {
+	matches := duplicatesMetadataPattern.FindStringSubmatch(body)
+	if len(matches) < 2 {
+		return nil, false
+	}
+
+	var refs []duplicateRef
+	if err := json.Unmarshal([]byte(matches[1]), &refs); err != nil {
+		return nil, false
+	}
+	return refs, true
+}
+
+// formatBackLinkComment renders the duplicate list posted on the original issue
+func formatBackLinkComment(refs []duplicateRef) (string, error) {
+	metadata, err := json.Marshal(refs)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🔗 **Duplicates of this issue**\n\n")
+	sb.WriteString("The following issues were closed as duplicates of this one:\n\n")
+	for _, r := range refs {
+		sb.WriteString(fmt.Sprintf("- %s#%d - %s\n", r.Repo, r.Number, r.Title))
+	}
+	sb.WriteString(fmt.Sprintf("\n<!-- simili-duplicates: %s -->\n\n", metadata))
+	sb.WriteString("---\n")
+	sb.WriteString("<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>")
+
+	return sb.String(), nil
+}
+
+// formatAuthorMention creates a comment that subscribes the duplicate's author to the original
+func formatAuthorMention(issue *models.Issue) string {
+	return fmt.Sprintf(`👋 @%s reported the same problem in %s#%d, which has been closed as a duplicate of this issue. Follow this issue for updates.
+
+---
+<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`,
+		issue.Author,
+		issue.FullRepo(),
+		issue.Number)
+}
@@ -0,0 +1,131 @@
+package triage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestBackLinkCommentRoundTrip(t *testing.T) {
+	refs := []duplicateRef{
+		{Repo: "org/app", Number: 12, Title: "Crash on save"},
+		{Repo: "org/cli", Number: 3, Title: "Save crashes --> sometimes"},
+	}
+
+	body, err := formatBackLinkComment(refs)
+	if err != nil {
+		t.Fatalf("formatBackLinkComment() error = %v", err)
+	}
+	if !strings.Contains(body, "- org/app#12 - Crash on save") {
+		t.Errorf("comment does not list the first duplicate:\n%s", body)
+	}
+
+	parsed, ok := parseDuplicateRefs(body)
+	if !ok {
+		t.Fatalf("parseDuplicateRefs() failed to find metadata")
+	}
+	if len(parsed) != len(refs) {
+		t.Fatalf("parseDuplicateRefs() returned %d refs, want %d", len(parsed), len(refs))
+	}
+	for i := range refs {
+		if parsed[i] != refs[i] {
+			t.Errorf("ref %d = %+v, want %+v", i, parsed[i], refs[i])
+		}
+	}
+
+	if _, ok := parseDuplicateRefs("plain comment"); ok {
+		t.Error("parseDuplicateRefs() matched a comment without metadata")
+	}
+}
+
+// fakeCloser records the comments written while closing a duplicate
+type fakeCloser struct {
+	comments []github.Comment
+	posted   []string
+	updated  map[int]string
+}
+
+func (f *fakeCloser) CloseIssue(ctx context.Context, org, repo string, number int, reason string) error {
+	return nil
+}
+
+func (f *fakeCloser) CloseAsDuplicate(ctx context.Context, org, repo string, number int, originalOrg, originalRepo string, originalNumber int) error {
+	return nil
+}
+
+func (f *fakeCloser) ListComments(ctx context.Context, org, repo string, number int) ([]github.Comment, error) {
+	return f.comments, nil
+}
+
+func (f *fakeCloser) UpdateComment(ctx context.Context, org, repo string, commentID int, body string) error {
+	if f.updated == nil {
+		f.updated = make(map[int]string)
+	}
+	f.updated[commentID] = body
+	return nil
+}
+
+func (f *fakeCloser) PostComment(ctx context.Context, org, repo string, number int, body string) error {
+	f.posted = append(f.posted, body)
+	return nil
+}
+
+func TestCloseAsDuplicate_WithoutDelayedActions(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Triage.Duplicate.BackLink = true
+	cfg.Triage.Duplicate.MentionAuthor = true
+	e := NewExecutor(nil, cfg, false)
+
+	gh := &fakeCloser{}
+	trust := pending.NewCommentStore(nil, &cfg.Defaults.DelayedActions)
+	issue := &models.Issue{Org: "org", Repo: "app", Number: 12, Title: "Crash on save", Author: "alice"}
+
+	if err := closeAsDuplicate(context.Background(), gh, trust, &e.config().Triage.Duplicate, issue, "https://github.com/org/app/issues/3"); err != nil {
+		t.Fatalf("closeAsDuplicate() error = %v", err)
+	}
+	if len(gh.posted) != 2 {
+		t.Fatalf("posted %d comments, want the back-link and the mention", len(gh.posted))
+	}
+	if !strings.Contains(gh.posted[0], "- org/app#12 - Crash on save") {
+		t.Errorf("back-link comment does not list the duplicate:\n%s", gh.posted[0])
+	}
+}
+
+func TestUpdateBackLink_TrustedAuthor(t *testing.T) {
+	existing, err := formatBackLinkComment([]duplicateRef{{Repo: "org/app", Number: 7, Title: "Old crash"}})
+	if err != nil {
+		t.Fatalf("formatBackLinkComment() error = %v", err)
+	}
+	issue := &models.Issue{Org: "org", Repo: "app", Number: 12, Title: "Crash on save"}
+	trust := pending.NewCommentStore(nil, &config.DelayedActionsConfig{})
+
+	tests := []struct {
+		name        string
+		author      string
+		wantUpdated bool
+	}{
+		{name: "bot comment is updated", author: pending.DefaultBotLogin, wantUpdated: true},
+		{name: "forged comment is ignored", author: "mallory", wantUpdated: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gh := &fakeCloser{comments: []github.Comment{{ID: 1, Body: existing, User: github.User{Login: tt.author}}}}
+
+			if err := updateBackLink(context.Background(), gh, trust, "org", "app", 3, issue); err != nil {
+				t.Fatalf("updateBackLink() error = %v", err)
+			}
+			if _, updated := gh.updated[1]; updated != tt.wantUpdated {
+				t.Errorf("comment updated = %v, want %v", updated, tt.wantUpdated)
+			}
+			if posted := len(gh.posted) == 1; posted == tt.wantUpdated {
+				t.Errorf("new comment posted = %v, want %v", posted, !tt.wantUpdated)
+			}
+		})
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/internal/match"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
@@ -16,6 +19,20 @@ type Classifier struct {
 	llm           llm.Provider
 	labels        []config.LabelConfig
 	minConfidence float64
+	mode          string
+	numExamples   int
+	examples      ExampleSource
+	labelSource   LabelLister // set when labels are synced from the repository
+	allow         []string
+	deny          []string
+	repoLabels    map[string][]config.LabelConfig // synced labels by org/repo
+	groups        []config.LabelGroupConfig
+	ruleSets      map[string]*match.Set // compiled keyword rules by lowercased label name
+}
+
+// ExampleSource finds already labeled issues similar to an issue
+type ExampleSource interface {
+	FindLabeled(ctx context.Context, issue *models.Issue, limit int) ([]vectordb.SearchResult, error)
 }
 
 // NewClassifier creates a new label classifier
@@ -24,50 +41,66 @@ func NewClassifier(provider llm.Provider, cfg *config.ClassifierConfig) *Classif
 		llm:           provider,
 		labels:        cfg.Labels,
 		minConfidence: cfg.MinConfidence,
+		mode:          cfg.Mode,
+		numExamples:   cfg.Examples,
+		allow:         cfg.Allow,
+		deny:          cfg.Deny,
+		groups:        cfg.Groups,
+		ruleSets:      make(map[string]*match.Set),
+		repoLabels:    make(map[string][]config.LabelConfig),
 	}
 }
 
+// NewClassifierWithExamples creates a label classifier that uses similar
+// labeled issues as few-shot examples, or as voters in kNN mode
+func NewClassifierWithExamples(provider llm.Provider, cfg *config.ClassifierConfig, examples ExampleSource) *Classifier {
+	c := NewClassifier(provider, cfg)
+	c.examples = examples
+	return c
+}
+
 // Classify analyzes an issue and suggests labels
 func (c *Classifier) Classify(ctx context.Context, issue *models.Issue) ([]LabelResult, error) {
+	labels := c.labelsFor(ctx, issue)
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
 	// First try rule-based classification
-	ruleResults := c.classifyByRules(issue)
+	ruleResults := c.classifyByRules(issue, labels)
+
+	examples := c.findExamples(ctx, issue)
+
+	if c.mode == "knn" {
+		return c.mergeResults(ruleResults, c.classifyByVotes(examples, labels)), nil
+	}
 
 	// Then use LLM for remaining labels
-	llmResults, err := c.classifyByLLM(ctx, issue, ruleResults)
+	llmResults, err := c.classifyByLLM(ctx, issue, labels, ruleResults, examples)
 	if err != nil {
 		// Fall back to rule-based only on LLM error
-		return ruleResults, nil
+		return selectExclusive(ruleResults, c.groups), nil
 	}
 
 	return c.mergeResults(ruleResults, llmResults), nil
 }
 
 // classifyByRules applies keyword-based rules
-func (c *Classifier) classifyByRules(issue *models.Issue) []LabelResult {
+func (c *Classifier) classifyByRules(issue *models.Issue, labels []config.LabelConfig) []LabelResult {
 	var results []LabelResult
-	text := strings.ToLower(issue.Title + " " + issue.Body)
 
-	for _, label := range c.labels {
-		if len(label.Keywords) == 0 {
+	for _, label := range labels {
+		set := c.ruleSet(label)
+		if set == nil || set.Len() == 0 {
 			continue
 		}
 
-		matchCount := 0
-		for _, kw := range label.Keywords {
-			if strings.Contains(text, strings.ToLower(kw)) {
-				matchCount++
-			}
-		}
-
-		if matchCount > 0 {
-			confidence := float64(matchCount) / float64(len(label.Keywords))
-			if confidence > 1.0 {
-				confidence = 1.0
-			}
+		confidence, matched := set.Score(issue)
+		if confidence > 0 {
 			results = append(results, LabelResult{
 				Label:      label.Name,
 				Confidence: confidence,
-				Reason:     "keyword match",
+				Reason:     fmt.Sprintf("keyword match (%s)", strings.Join(matched, ", ")),
 			})
 		}
 	}
@@ -75,8 +108,23 @@ func (c *Classifier) classifyByRules(issue *models.Issue) []LabelResult {
 	return results
 }
 
+// ruleSet returns the compiled keyword rules of a label
+func (c *Classifier) ruleSet(label config.LabelConfig) *match.Set {
+	key := strings.ToLower(label.Name)
+	if set, ok := c.ruleSets[key]; ok {
+		return set
+	}
+
+	set, err := match.Compile(append(match.FromKeywords(label.Keywords), label.Rules...))
+	if err != nil {
+		log.Printf("Warning: skipping keyword rules for label %s: %v", label.Name, err)
+	}
+	c.ruleSets[key] = set
+	return set
+}
+
 // classifyByLLM uses the LLM to classify labels
-func (c *Classifier) classifyByLLM(ctx context.Context, issue *models.Issue, existingResults []LabelResult) ([]LabelResult, error) {
+func (c *Classifier) classifyByLLM(ctx context.Context, issue *models.Issue, labels []config.LabelConfig, existingResults []LabelResult, examples []vectordb.SearchResult) ([]LabelResult, error) {
 	// Build list of labels not yet classified by rules
 	classifiedLabels := make(map[string]bool)
 	for _, r := range existingResults {
@@ -84,9 +132,15 @@ func (c *Classifier) classifyByLLM(ctx context.Context, issue *models.Issue, exi
 	}
 
 	var labelsToClassify []string
-	for _, label := range c.labels {
+	var labelList strings.Builder
+	for _, label := range labels {
 		if !classifiedLabels[label.Name] {
 			labelsToClassify = append(labelsToClassify, label.Name)
+			labelList.WriteString("- " + label.Name)
+			if label.Description != "" {
+				labelList.WriteString(": " + label.Description)
+			}
+			labelList.WriteString("\n")
 		}
 	}
 
@@ -103,12 +157,14 @@ Only include labels that are relevant. Be conservative - only assign labels you
 Issue Body:
 %s
 
-Available Labels: %s
-
+Available Labels:
+%s%s%s
 Classify this issue. Return JSON array only, no other text.`,
 		issue.Title,
 		truncateText(issue.Body, 2000),
-		strings.Join(labelsToClassify, ", "))
+		labelList.String(),
+		formatExclusiveGroups(labelsToClassify, c.groups),
+		formatExamples(examples, labels))
 
 	response, err := c.llm.CompleteWithSystem(ctx, system, prompt)
 	if err != nil {
@@ -118,6 +174,82 @@ Classify this issue. Return JSON array only, no other text.`,
 	return c.parseClassificationResponse(response, labelsToClassify)
 }
 
+// findExamples retrieves similar labeled issues when an example source is set
+func (c *Classifier) findExamples(ctx context.Context, issue *models.Issue) []vectordb.SearchResult {
+	if c.examples == nil || c.numExamples <= 0 {
+		return nil
+	}
+
+	examples, err := c.examples.FindLabeled(ctx, issue, c.numExamples)
+	if err != nil {
+		log.Printf("Warning: failed to find labeled examples: %v", err)
+		return nil
+	}
+	return examples
+}
+
+// formatExamples renders similar labeled issues as few-shot examples,
+// keeping only the candidate labels
+func formatExamples(examples []vectordb.SearchResult, candidates []config.LabelConfig) string {
+	var sb strings.Builder
+	for _, e := range examples {
+		labels := knownLabels(e.Issue.Labels, candidates)
+		if len(labels) == 0 {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("\nSimilar issues and the labels maintainers gave them:\n")
+		}
+		sb.WriteString(fmt.Sprintf("- %q -> %s\n", e.Issue.Title, strings.Join(labels, ", ")))
+	}
+	return sb.String()
+}
+
+// classifyByVotes scores each label by the similarity-weighted share of
+// neighbors carrying it
+func (c *Classifier) classifyByVotes(examples []vectordb.SearchResult, labels []config.LabelConfig) []LabelResult {
+	var total float64
+	votes := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, e := range examples {
+		total += e.Score
+		for _, l := range knownLabels(e.Issue.Labels, labels) {
+			votes[l] += e.Score
+			counts[l]++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var results []LabelResult
+	for _, label := range labels {
+		if votes[label.Name] == 0 {
+			continue
+		}
+		results = append(results, LabelResult{
+			Label:      label.Name,
+			Confidence: votes[label.Name] / total,
+			Reason:     fmt.Sprintf("kNN vote (%d/%d similar issues)", counts[label.Name], len(examples)),
+		})
+	}
+	return results
+}
+
+// knownLabels filters labels to the candidate labels
+func knownLabels(labels []string, candidates []config.LabelConfig) []string {
+	var known []string
+	for _, l := range labels {
+		for _, cfgLabel := range candidates {
+			if strings.EqualFold(cfgLabel.Name, l) {
+				known = append(known, cfgLabel.Name)
+				break
+			}
+		}
+	}
+	return known
+}
+
 // parseClassificationResponse parses the LLM response
 func (c *Classifier) parseClassificationResponse(response string, validLabels []string) ([]LabelResult, error) {
 	response = strings.TrimSpace(response)
@@ -176,7 +308,7 @@ func (c *Classifier) mergeResults(ruleResults, llmResults []LabelResult) []Label
 		}
 	}
 
-	return results
+	return selectExclusive(results, c.groups)
 }
 
 // truncateText limits text length
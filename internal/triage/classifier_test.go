@@ -0,0 +1,82 @@
+package triage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/vectordb"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// staticExamples returns fixed labeled neighbors
+type staticExamples []vectordb.SearchResult
+
+func (s staticExamples) FindLabeled(ctx context.Context, issue *models.Issue, limit int) ([]vectordb.SearchResult, error) {
+	return s, nil
+}
+
+func labeled(title string, score float64, labels ...string) vectordb.SearchResult {
+	return vectordb.SearchResult{Issue: models.Issue{Title: title, Labels: labels}, Score: score}
+}
+
+func TestClassifier_KNNVote(t *testing.T) {
+	cfg := &config.ClassifierConfig{
+		Labels:        []config.LabelConfig{{Name: "bug"}, {Name: "docs"}},
+		MinConfidence: 0.5,
+		Mode:          "knn",
+		Examples:      3,
+	}
+	examples := staticExamples{
+		labeled("Crash on save", 0.9, "bug"),
+		labeled("Panic in parser", 0.8, "bug", "triaged"),
+		labeled("Typo in README", 0.3, "docs"),
+	}
+
+	// No LLM: kNN mode must not call it
+	c := NewClassifierWithExamples(nil, cfg, examples)
+	results, err := c.Classify(context.Background(), &models.Issue{Title: "App crashes"})
+	if err != nil {
+		t.Fatalf("Classify() error = %v", err)
+	}
+
+	if len(results) != 1 || results[0].Label != "bug" {
+		t.Fatalf("Classify() = %+v, want only bug", results)
+	}
+	if want := 1.7 / 2.0; results[0].Confidence < want-1e-9 || results[0].Confidence > want+1e-9 {
+		t.Errorf("bug confidence = %v, want %v", results[0].Confidence, want)
+	}
+}
+
+func TestClassifier_SingleKeyword(t *testing.T) {
+	cfg := &config.ClassifierConfig{
+		Labels:        []config.LabelConfig{{Name: "bug", Keywords: []string{"crash"}}, {Name: "docs", Keywords: []string{"readme", "typo"}}},
+		MinConfidence: 0.7,
+		Mode:          "knn",
+	}
+
+	c := NewClassifierWithExamples(nil, cfg, staticExamples{})
+	results, err := c.Classify(context.Background(), &models.Issue{Title: "App crashes on start"})
+	if err != nil {
+		t.Fatalf("Classify() error = %v", err)
+	}
+
+	if len(results) != 1 || results[0].Label != "bug" || results[0].Confidence != 1 {
+		t.Errorf("Classify() = %+v, want bug with confidence 1", results)
+	}
+}
+
+func TestClassifier_FormatExamples(t *testing.T) {
+	got := formatExamples([]vectordb.SearchResult{
+		labeled("Crash on save", 0.9, "bug", "triaged"),
+		labeled("Unrelated", 0.5, "wontfix"),
+	}, []config.LabelConfig{{Name: "bug"}})
+
+	if !strings.Contains(got, `"Crash on save" -> bug`) {
+		t.Errorf("formatExamples() missing labeled example:\n%s", got)
+	}
+	if strings.Contains(got, "Unrelated") || strings.Contains(got, "triaged") {
+		t.Errorf("formatExamples() included unconfigured labels:\n%s", got)
+	}
+}
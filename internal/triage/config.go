@@ -32,15 +32,19 @@ type DuplicateResult struct {
 	IsDuplicate bool           `json:"is_duplicate"`
 	Similarity  float64        `json:"similarity"`
 	Original    *models.Issue  `json:"original,omitempty"`
+	Matched     *models.Issue  `json:"matched,omitempty"` // nearest issue when Original is its cluster's canonical issue
 	ShouldClose bool           `json:"should_close"`
+	Verdict     string         `json:"verdict,omitempty"`   // LLM verification verdict, if enabled
+	Rationale   string         `json:"rationale,omitempty"` // LLM explanation of the verdict
 }
 
 // Action represents an action to take on the issue
 type Action struct {
-	Type    ActionType `json:"type"`
-	Label   string     `json:"label,omitempty"`
-	Comment string     `json:"comment,omitempty"`
-	Reason  string     `json:"reason,omitempty"`
+	Type     ActionType `json:"type"`
+	Label    string     `json:"label,omitempty"`
+	Comment  string     `json:"comment,omitempty"`
+	Assignee string     `json:"assignee,omitempty"`
+	Reason   string     `json:"reason,omitempty"`
 }
 
 // ActionType represents the type of action
@@ -51,6 +55,8 @@ const (
 	ActionRemoveLabel ActionType = "remove_label"
 	ActionComment     ActionType = "comment"
 	ActionClose       ActionType = "close"
+	ActionLock        ActionType = "lock"
+	ActionAssign      ActionType = "assign"
 )
 
 // IssueContext contains all information about an issue for triage
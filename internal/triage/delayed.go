@@ -0,0 +1,544 @@
+package triage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/audit"
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// needsInfoClosedMarker tags the comment posted when a needs-info issue is
+// closed, so that an author reply can reopen it
+const needsInfoClosedMarker = "<!-- simili-needs-info-closed -->"
+
+// delayableActions are the action types that can wait for approval
+var delayableActions = map[ActionType]bool{
+	ActionAddLabel:    true,
+	ActionRemoveLabel: true,
+	ActionComment:     true,
+	ActionLock:        true,
+	ActionAssign:      true,
+}
+
+// splitDelayed separates the actions to run now from those configured to
+// wait for the delayed action window
+func (e *Executor) splitDelayed(actions []Action) (now, later []Action) {
+	if e.cfg == nil || e.pendingManager == nil || !e.cfg.Defaults.DelayedActions.Enabled {
+		return actions, nil
+	}
+
+	delayed := make(map[ActionType]bool)
+	for _, t := range e.cfg.Defaults.DelayedActions.Actions {
+		if delayableActions[ActionType(t)] {
+			delayed[ActionType(t)] = true
+		}
+	}
+
+	for _, action := range actions {
+		if delayed[action.Type] {
+			later = append(later, action)
+		} else {
+			now = append(now, action)
+		}
+	}
+	return now, later
+}
+
+// ScheduleActions posts a comment listing the actions and schedules them
+// as one pending triage action
+func (e *Executor) ScheduleActions(ctx context.Context, issue *models.Issue, actions []Action) error {
+	if e.pendingManager == nil || e.cfg == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
+	if e.dryRun {
+		log.Printf("[DRY RUN] Would schedule %d delayed actions on issue #%d", len(actions), issue.Number)
+		return nil
+	}
+
+	cfg := e.cfg.Defaults.DelayedActions
+	expiresAt := time.Now().Add(time.Duration(cfg.DelayHours) * time.Hour)
+
+	action := &pending.PendingAction{
+		Type:        pending.ActionTypeTriage,
+		Org:         issue.Org,
+		Repo:        issue.Repo,
+		IssueNumber: issue.Number,
+		Operations:  toOperations(actions),
+		ScheduledAt: time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+
+	comment, err := formatDelayedActionsComment(actions, expiresAt, cfg, action)
+	if err != nil {
+		return fmt.Errorf("failed to format delayed actions comment: %w", err)
+	}
+	commentID, err := e.client.PostCommentWithID(ctx, issue.Org, issue.Repo, issue.Number, comment)
+	if err != nil {
+		return fmt.Errorf("failed to post delayed actions comment: %w", err)
+	}
+
+	return e.pendingManager.ScheduleTriage(ctx, issue, action.Operations, commentID, cfg.DelayHours)
+}
+
+// ScheduleNeedsInfoClose schedules closing an issue unless its author replies
+// within the configured number of days. Issues that already have a pending
+// close are left alone.
+func (e *Executor) ScheduleNeedsInfoClose(ctx context.Context, issue *models.Issue) error {
+	if e.pendingManager == nil || e.cfg == nil || !e.cfg.Defaults.DelayedActions.Enabled || !e.cfg.Defaults.DelayedActions.NeedsInfo.Enabled {
+		return nil
+	}
+	if hasLabel(issue.Labels, pending.LabelPendingNeedsInfo) {
+		return nil
+	}
+	if e.dryRun {
+		log.Printf("[DRY RUN] Would schedule needs-info close on issue #%d", issue.Number)
+		return nil
+	}
+
+	cfg := e.cfg.Defaults.DelayedActions
+	delayHours := cfg.NeedsInfo.Days * 24
+	expiresAt := time.Now().Add(time.Duration(delayHours) * time.Hour)
+
+	action := &pending.PendingAction{
+		Type:        pending.ActionTypeNeedsInfo,
+		Org:         issue.Org,
+		Repo:        issue.Repo,
+		IssueNumber: issue.Number,
+		ScheduledAt: time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+
+	comment, err := formatNeedsInfoComment(issue, e.cfg.Triage.Quality.NeedsInfoLabel, expiresAt, cfg, action)
+	if err != nil {
+		return fmt.Errorf("failed to format needs-info comment: %w", err)
+	}
+	commentID, err := e.client.PostCommentWithID(ctx, issue.Org, issue.Repo, issue.Number, comment)
+	if err != nil {
+		return fmt.Errorf("failed to post needs-info comment: %w", err)
+	}
+
+	return e.pendingManager.ScheduleNeedsInfoClose(ctx, issue, commentID, delayHours)
+}
+
+// ScheduleNeedsInfoCloses schedules a close for every open issue of a
+// repository labeled as needing information that has none pending yet
+func (e *Executor) ScheduleNeedsInfoCloses(ctx context.Context, org, repo string) (int, error) {
+	if e.cfg == nil || !e.cfg.Defaults.DelayedActions.NeedsInfo.Enabled {
+		return 0, nil
+	}
+
+	issues, err := e.client.ListIssuesByLabel(ctx, org, repo, e.cfg.Triage.Quality.NeedsInfoLabel)
+	if err != nil {
+		return 0, err
+	}
+
+	scheduled := 0
+	for _, issue := range issues {
+		if hasLabel(issue.Labels, pending.LabelPendingNeedsInfo) {
+			continue
+		}
+		if err := e.ScheduleNeedsInfoClose(ctx, issue); err != nil {
+			log.Printf("Warning: failed to schedule needs-info close for #%d: %v", issue.Number, err)
+			continue
+		}
+		scheduled++
+	}
+	return scheduled, nil
+}
+
+// ProcessPendingAction processes a pending triage action or needs-info close
+func (e *Executor) ProcessPendingAction(ctx context.Context, action *pending.PendingAction) error {
+	if e.pendingManager == nil || e.cfg == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
+
+	issue, err := e.client.GetIssue(ctx, action.Org, action.Repo, action.IssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+
+	if action.Type == pending.ActionTypeNeedsInfo {
+		resolved, err := e.needsInfoResolved(ctx, action, issue)
+		if err != nil || resolved {
+			return err
+		}
+	}
+
+	if err := e.pendingManager.Validate(ctx, action); err != nil {
+		return err
+	}
+
+	// Check reactions
+	decision, err := e.pendingManager.Decide(ctx, action)
+	if err != nil {
+		return fmt.Errorf("failed to check reactions: %w", err)
+	}
+
+	if e.dryRun {
+		return nil
+	}
+
+	if decision.Outcome == pending.DecisionCancel {
+		return e.CancelPendingAction(ctx, action, decision.DecidedBy)
+	}
+
+	if decision.Outcome == pending.DecisionApprove && e.cfg.Defaults.DelayedActions.ExecuteOnApprove {
+		log.Printf("Pending %s on %s/%s#%d approved by %s", action.Type, action.Org, action.Repo, action.IssueNumber, pending.FormatDecidedBy(decision.DecidedBy))
+		return e.applyPending(ctx, action, issue)
+	}
+
+	if action.IsExpired() {
+		return e.applyPending(ctx, action, issue)
+	}
+
+	return nil // Not expired yet
+}
+
+// ApprovePendingAction carries out a pending triage action or needs-info
+// close without waiting for it to expire. approvedBy is recorded when users
+// approved it explicitly.
+func (e *Executor) ApprovePendingAction(ctx context.Context, action *pending.PendingAction, approvedBy []string) error {
+	if e.pendingManager == nil || e.cfg == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
+	if e.dryRun {
+		return nil
+	}
+
+	if err := e.pendingManager.Validate(ctx, action); err != nil {
+		return err
+	}
+
+	if len(approvedBy) > 0 {
+		if err := e.pendingManager.Record(ctx, action, pending.StateDecided, approvedBy); err != nil {
+			return err
+		}
+	}
+
+	issue, err := e.client.GetIssue(ctx, action.Org, action.Repo, action.IssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	return e.applyPending(ctx, action, issue)
+}
+
+// CancelPendingAction cancels a pending triage action or needs-info close on
+// behalf of the users who decided. A cancelled needs-info close also drops
+// the needs-info label so the issue is not scheduled again.
+func (e *Executor) CancelPendingAction(ctx context.Context, action *pending.PendingAction, decidedBy []string) error {
+	if e.pendingManager == nil || e.cfg == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
+	if e.dryRun {
+		return nil
+	}
+
+	if err := e.pendingManager.Record(ctx, action, pending.StateDecided, decidedBy); err != nil {
+		return err
+	}
+	if err := e.pendingManager.Cancel(ctx, action); err != nil {
+		return err
+	}
+
+	what := "The delayed actions have"
+	if action.Type == pending.ActionTypeNeedsInfo {
+		what = "Auto-close has"
+		if err := e.client.RemoveLabel(ctx, action.Org, action.Repo, action.IssueNumber, e.cfg.Triage.Quality.NeedsInfoLabel); err != nil {
+			log.Printf("Warning: failed to remove needs-info label from #%d: %v", action.IssueNumber, err)
+		}
+	}
+
+	comment := fmt.Sprintf("✅ %s been cancelled by %s.\n\n---\n<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>", what, pending.FormatDecidedBy(decidedBy))
+	return e.client.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, comment)
+}
+
+// ReopenNeedsInfo reopens an issue that was closed for lack of information
+// when its author comments on it. It reports whether the issue was reopened.
+func (e *Executor) ReopenNeedsInfo(ctx context.Context, issue *models.Issue, commenter string) (bool, error) {
+	if e.cfg == nil || !e.cfg.Defaults.DelayedActions.NeedsInfo.Enabled {
+		return false, nil
+	}
+	label := e.cfg.Triage.Quality.NeedsInfoLabel
+	if issue.State != "closed" || !strings.EqualFold(commenter, issue.Author) || !hasLabel(issue.Labels, label) {
+		return false, nil
+	}
+
+	comments, err := e.client.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+	if err != nil {
+		return false, err
+	}
+	trust := pending.NewCommentStore(e.client, &e.cfg.Defaults.DelayedActions)
+	if !closedForNeedsInfo(ctx, comments, trust) {
+		return false, nil
+	}
+
+	if e.dryRun {
+		log.Printf("[DRY RUN] Would reopen issue #%d after author reply", issue.Number)
+		return true, nil
+	}
+
+	err = e.client.ReopenIssue(ctx, issue.Org, issue.Repo, issue.Number)
+	e.audit.Record(audit.Entry{
+		Org:     issue.Org,
+		Repo:    issue.Repo,
+		Issue:   issue.Number,
+		Action:  "reopen",
+		Actor:   commenter,
+		Rule:    "needs-info",
+		Outcome: audit.OutcomeOf(err, audit.OutcomeExecuted),
+		Error:   audit.ErrorText(err),
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := e.client.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, label); err != nil {
+		log.Printf("Warning: failed to remove needs-info label from #%d: %v", issue.Number, err)
+	}
+	return true, nil
+}
+
+// closedForNeedsInfo reports whether simili closed the issue for lack of
+// information. Only the marker in a trusted comment counts, so an author
+// cannot paste it to reopen an issue a maintainer closed.
+func closedForNeedsInfo(ctx context.Context, comments []github.Comment, trust *pending.CommentStore) bool {
+	for _, c := range comments {
+		if strings.Contains(c.Body, needsInfoClosedMarker) && trust.Trusted(ctx, c.User) {
+			return true
+		}
+	}
+	return false
+}
+
+// needsInfoResolved cancels a needs-info close that no longer applies: the
+// issue was closed, lost its label or the author replied
+func (e *Executor) needsInfoResolved(ctx context.Context, action *pending.PendingAction, issue *models.Issue) (bool, error) {
+	label := e.cfg.Triage.Quality.NeedsInfoLabel
+
+	reason := ""
+	switch {
+	case issue.State == "closed":
+		reason = "issue is closed"
+	case !hasLabel(issue.Labels, label):
+		reason = "label was removed"
+	default:
+		comments, err := e.client.ListComments(ctx, issue.Org, issue.Repo, issue.Number)
+		if err != nil {
+			return false, fmt.Errorf("failed to list comments: %w", err)
+		}
+		if !authorReplied(comments, issue.Author, action.ScheduledAt) {
+			return false, nil
+		}
+		reason = "author replied"
+	}
+
+	log.Printf("Cancelling needs-info close on %s/%s#%d: %s", action.Org, action.Repo, action.IssueNumber, reason)
+	if e.dryRun {
+		return true, nil
+	}
+	if err := e.pendingManager.Cancel(ctx, action); err != nil {
+		return true, err
+	}
+	if reason == "author replied" {
+		if err := e.client.RemoveLabel(ctx, issue.Org, issue.Repo, issue.Number, label); err != nil {
+			log.Printf("Warning: failed to remove needs-info label from #%d: %v", issue.Number, err)
+		}
+	}
+	return true, nil
+}
+
+// applyPending carries out a pending action and marks it complete
+func (e *Executor) applyPending(ctx context.Context, action *pending.PendingAction, issue *models.Issue) error {
+	switch action.Type {
+	case pending.ActionTypeTriage:
+		for _, op := range action.Operations {
+			a := fromOperation(op)
+			if !delayableActions[a.Type] {
+				log.Printf("Warning: skipping %s, which cannot be delayed", a.Type)
+				continue
+			}
+			if err := e.executeAction(ctx, issue, a, &Result{}); err != nil {
+				log.Printf("Error executing action %s: %v", a.Type, err)
+			}
+		}
+
+	case pending.ActionTypeNeedsInfo:
+		comment := fmt.Sprintf(`Closing this issue because we did not hear back from @%s within %d days.
+
+@%s, reply with the requested information and the issue will be reopened.
+
+%s
+
+---
+<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`, issue.Author, e.cfg.Defaults.DelayedActions.NeedsInfo.Days, issue.Author, needsInfoClosedMarker)
+		if err := e.client.PostComment(ctx, issue.Org, issue.Repo, issue.Number, comment); err != nil {
+			return err
+		}
+		err := e.client.CloseIssue(ctx, issue.Org, issue.Repo, issue.Number, github.CloseReasonNotPlanned)
+		e.audit.Record(audit.Entry{
+			Org:     issue.Org,
+			Repo:    issue.Repo,
+			Issue:   issue.Number,
+			Action:  string(ActionClose),
+			Rule:    "needs-info",
+			Outcome: audit.OutcomeOf(err, audit.OutcomeExecuted),
+			Error:   audit.ErrorText(err),
+		})
+		if err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("unknown pending action type: %s", action.Type)
+	}
+
+	if err := e.pendingManager.Complete(ctx, action); err != nil {
+		fmt.Printf("Warning: failed to remove pending label from %s/%s#%d: %v\n", action.Org, action.Repo, action.IssueNumber, err)
+	}
+	return nil
+}
+
+// authorReplied reports whether the author commented after a point in time
+func authorReplied(comments []github.Comment, author string, since time.Time) bool {
+	for _, c := range comments {
+		if strings.EqualFold(c.User.Login, author) && c.CreatedAt.After(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// toOperations converts triage actions for storage in a pending action
+func toOperations(actions []Action) []pending.Operation {
+	ops := make([]pending.Operation, 0, len(actions))
+	for _, a := range actions {
+		ops = append(ops, pending.Operation{
+			Type:     string(a.Type),
+			Label:    a.Label,
+			Comment:  a.Comment,
+			Assignee: a.Assignee,
+			Reason:   a.Reason,
+		})
+	}
+	return ops
+}
+
+// fromOperation converts a stored operation back into a triage action
+func fromOperation(op pending.Operation) Action {
+	return Action{
+		Type:     ActionType(op.Type),
+		Label:    op.Label,
+		Comment:  op.Comment,
+		Assignee: op.Assignee,
+		Reason:   op.Reason,
+	}
+}
+
+// describeAction renders an action as a list item for the pending comment
+func describeAction(a Action) string {
+	var s string
+	switch a.Type {
+	case ActionAddLabel:
+		s = fmt.Sprintf("Add label `%s`", a.Label)
+	case ActionRemoveLabel:
+		s = fmt.Sprintf("Remove label `%s`", a.Label)
+	case ActionComment:
+		s = "Post a comment"
+	case ActionLock:
+		s = "Lock the conversation"
+	case ActionAssign:
+		s = fmt.Sprintf("Assign @%s", a.Assignee)
+	default:
+		s = string(a.Type)
+	}
+	if a.Reason != "" {
+		s += " (" + a.Reason + ")"
+	}
+	return "- " + s
+}
+
+// formatDelayedActionsComment creates the comment announcing delayed actions
+func formatDelayedActionsComment(actions []Action, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction) (string, error) {
+	metadata, err := pending.FormatPendingActionMetadata(action, cfg.SigningSecret)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(actions))
+	for _, a := range actions {
+		lines = append(lines, describeAction(a))
+	}
+
+	return fmt.Sprintf(`⏳ **The following actions will be applied in %d hours**
+
+%s
+
+**React to this comment:**
+- 👍 (%s) to approve and apply them
+- 👎 (%s) to cancel them
+%s
+**Deadline**: %s
+
+If no reaction is provided, the actions will be applied automatically.
+
+%s
+
+---
+<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`,
+		cfg.DelayHours,
+		strings.Join(lines, "\n"),
+		cfg.ApproveReaction,
+		cfg.CancelReaction,
+		pending.FormatAuthorization(cfg.Authorization),
+		expiresAt.Format("2006-01-02 15:04 MST"),
+		metadata,
+	), nil
+}
+
+// formatNeedsInfoComment creates the comment announcing a needs-info close
+func formatNeedsInfoComment(issue *models.Issue, label string, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction) (string, error) {
+	metadata, err := pending.FormatPendingActionMetadata(action, cfg.SigningSecret)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`⏳ **This issue will be closed in %d days unless @%s replies**
+
+It is labeled `+"`%s`"+`: please add the requested details. Any reply from the author keeps it open.
+
+**React to this comment:**
+- 👍 (%s) to approve and close it now
+- 👎 (%s) to cancel and keep it open
+%s
+**Deadline**: %s
+
+%s
+
+---
+<sub>🤖 Powered by [Simili](https://github.com/Kavirubc/gh-simili)</sub>`,
+		cfg.NeedsInfo.Days,
+		issue.Author,
+		label,
+		cfg.ApproveReaction,
+		cfg.CancelReaction,
+		pending.FormatAuthorization(cfg.Authorization),
+		expiresAt.Format("2006-01-02 15:04 MST"),
+		metadata,
+	), nil
+}
+
+// hasLabel reports whether a label is in the list, ignoring case
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
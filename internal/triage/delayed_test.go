@@ -0,0 +1,106 @@
+package triage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/internal/pending"
+)
+
+func TestSplitDelayed(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Defaults.DelayedActions.Enabled = true
+	cfg.Defaults.DelayedActions.Actions = []string{"lock", "assign", "close"}
+
+	actions := []Action{
+		{Type: ActionAddLabel, Label: "bug"},
+		{Type: ActionLock},
+		{Type: ActionClose},
+		{Type: ActionAssign, Assignee: "octocat"},
+	}
+
+	e := &Executor{cfg: cfg, pendingManager: &pending.Manager{}}
+	now, later := e.splitDelayed(actions)
+	if len(now) != 2 || now[0].Type != ActionAddLabel || now[1].Type != ActionClose {
+		t.Errorf("now = %+v, want add_label and close", now)
+	}
+	if len(later) != 2 || later[0].Type != ActionLock || later[1].Type != ActionAssign {
+		t.Errorf("later = %+v, want lock and assign", later)
+	}
+
+	cfg.Defaults.DelayedActions.Enabled = false
+	now, later = e.splitDelayed(actions)
+	if len(now) != len(actions) || len(later) != 0 {
+		t.Errorf("with delayed actions disabled: now = %d, later = %d", len(now), len(later))
+	}
+}
+
+func TestOperationRoundTrip(t *testing.T) {
+	actions := []Action{
+		{Type: ActionAddLabel, Label: "bug", Reason: "classified"},
+		{Type: ActionAssign, Assignee: "octocat"},
+		{Type: ActionComment, Comment: "Thanks!"},
+	}
+
+	ops := toOperations(actions)
+	for i, op := range ops {
+		if got := fromOperation(op); got != actions[i] {
+			t.Errorf("fromOperation(toOperations(%+v)) = %+v", actions[i], got)
+		}
+	}
+}
+
+func TestAuthorReplied(t *testing.T) {
+	scheduled := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	comment := func(login string, at time.Time) github.Comment {
+		return github.Comment{User: github.User{Login: login}, CreatedAt: at}
+	}
+
+	tests := []struct {
+		name     string
+		comments []github.Comment
+		want     bool
+	}{
+		{"no comments", nil, false},
+		{"author before scheduling", []github.Comment{comment("author", scheduled.Add(-time.Hour))}, false},
+		{"someone else after", []github.Comment{comment("maintainer", scheduled.Add(time.Hour))}, false},
+		{"author after", []github.Comment{comment("maintainer", scheduled.Add(time.Hour)), comment("Author", scheduled.Add(2*time.Hour))}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := authorReplied(tt.comments, "author", scheduled); got != tt.want {
+				t.Errorf("authorReplied() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosedForNeedsInfo(t *testing.T) {
+	trust := pending.NewCommentStore(nil, &config.DelayedActionsConfig{BotLogins: []string{"simili-bot"}})
+	marker := "Closing for now.\n" + needsInfoClosedMarker
+	comment := func(login, body string) github.Comment {
+		return github.Comment{User: github.User{Login: login}, Body: body}
+	}
+
+	tests := []struct {
+		name     string
+		comments []github.Comment
+		want     bool
+	}{
+		{"no marker", []github.Comment{comment("simili-bot", "Please add details")}, false},
+		{"closed by simili", []github.Comment{comment("simili-bot", marker)}, true},
+		{"marker pasted by the author", []github.Comment{comment("author", marker)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := closedForNeedsInfo(context.Background(), tt.comments, trust); got != tt.want {
+				t.Errorf("closedForNeedsInfo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,9 +3,12 @@ package triage
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
+	"github.com/Kavirubc/gh-simili/internal/audit"
+	"github.com/Kavirubc/gh-simili/internal/cluster"
 	"github.com/Kavirubc/gh-simili/internal/config"
 	"github.com/Kavirubc/gh-simili/internal/github"
 	"github.com/Kavirubc/gh-simili/internal/pending"
@@ -19,7 +22,9 @@ type DuplicateChecker struct {
 	requireConfirm     bool
 	gh                 *github.Client
 	pendingManager     *pending.Manager
+	audit              *audit.Log
 	cfg                *config.Config
+	clusters           *cluster.Manager
 	dryRun             bool
 }
 
@@ -38,6 +43,7 @@ func NewDuplicateCheckerWithDelayedActions(cfg *config.DuplicateConfig, gh *gith
 		requireConfirm:     cfg.RequireConfirm,
 		gh:                 gh,
 		pendingManager:     pending.NewManager(gh, fullCfg),
+		audit:              audit.New(fullCfg),
 		cfg:                fullCfg,
 		dryRun:             false,
 	}
@@ -50,6 +56,7 @@ func NewDuplicateCheckerWithDelayedActionsAndDryRun(cfg *config.DuplicateConfig,
 		requireConfirm:     cfg.RequireConfirm,
 		gh:                 gh,
 		pendingManager:     pending.NewManager(gh, fullCfg),
+		audit:              audit.New(fullCfg),
 		cfg:                fullCfg,
 		dryRun:             dryRun,
 	}
@@ -101,6 +108,25 @@ func (d *DuplicateChecker) Check(similarIssues []vectordb.SearchResult) *Duplica
 	}
 }
 
+// PointToCanonical redirects a duplicate match to the canonical issue of the
+// match's cluster so duplicates don't chain onto other duplicates
+func (d *DuplicateChecker) PointToCanonical(ctx context.Context, result *DuplicateResult) {
+	if d.clusters == nil || result == nil || !result.IsDuplicate || result.Original == nil {
+		return
+	}
+
+	canonical, err := d.clusters.Canonical(ctx, result.Original)
+	if err != nil {
+		log.Printf("Warning: failed to resolve canonical issue for #%d: %v", result.Original.Number, err)
+		return
+	}
+
+	if canonical.UUID() != result.Original.UUID() {
+		result.Matched = result.Original
+		result.Original = canonical
+	}
+}
+
 // FormatDuplicateComment creates a comment for duplicate issues
 func (d *DuplicateChecker) FormatDuplicateComment(result *DuplicateResult, autoClose bool) string {
 	if result.Original == nil {
@@ -120,7 +146,14 @@ func (d *DuplicateChecker) FormatDuplicateComment(result *DuplicateResult, autoC
 		result.Original.Title,
 		result.Original.URL))
 
-	sb.WriteString(fmt.Sprintf("**Similarity:** %.0f%%\n\n", result.Similarity*100))
+	if result.Matched != nil {
+		sb.WriteString(fmt.Sprintf("**Closest match:** #%d (a known duplicate of the original)\n", result.Matched.Number))
+	}
+	sb.WriteString(fmt.Sprintf("**Similarity:** %.0f%%\n", result.Similarity*100))
+	if result.Rationale != "" {
+		sb.WriteString(fmt.Sprintf("**Why:** %s\n", result.Rationale))
+	}
+	sb.WriteString("\n")
 
 	if autoClose {
 		sb.WriteString("If you believe this is not a duplicate, please comment and we will reopen it.\n\n")
@@ -234,18 +267,15 @@ func (d *DuplicateChecker) ProcessPendingClose(ctx context.Context, action *pend
 	}
 	if issue.State == "closed" {
 		// Already closed, just remove label
-		return d.pendingManager.Cancel(ctx, action)
+		return d.pendingManager.Complete(ctx, action)
+	}
+
+	if err := d.pendingManager.Validate(ctx, action); err != nil {
+		return err
 	}
 
 	// Check reactions
-	decision, err := d.gh.CheckReactionDecision(
-		ctx,
-		action.Org,
-		action.Repo,
-		action.CommentID,
-		d.cfg.Defaults.DelayedActions.ApproveReaction,
-		d.cfg.Defaults.DelayedActions.CancelReaction,
-	)
+	decision, err := d.pendingManager.Decide(ctx, action)
 	if err != nil {
 		return fmt.Errorf("failed to check reactions: %w", err)
 	}
@@ -254,49 +284,103 @@ func (d *DuplicateChecker) ProcessPendingClose(ctx context.Context, action *pend
 		return nil
 	}
 
-	if decision == "cancel" {
+	if decision.Outcome == pending.DecisionCancel {
 		// User cancelled, add potential-duplicate label instead
-		if err := d.pendingManager.Cancel(ctx, action); err != nil {
-			return err
-		}
-		if err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"potential-duplicate"}); err != nil {
-			return err
-		}
-		cancelComment := formatCloseCancelledComment()
-		return d.gh.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, cancelComment)
+		return d.CancelPendingClose(ctx, action, decision.DecidedBy)
 	}
 
-	if decision == "approve" && d.cfg.Defaults.DelayedActions.ExecuteOnApprove {
+	if decision.Outcome == pending.DecisionApprove && d.cfg.Defaults.DelayedActions.ExecuteOnApprove {
 		// User approved, close immediately
-		return d.executeClose(ctx, action)
+		log.Printf("Close of %s/%s#%d approved by %s", action.Org, action.Repo, action.IssueNumber, pending.FormatDecidedBy(decision.DecidedBy))
+		return d.executeClose(ctx, action, issue, decision.DecidedBy)
 	}
 
 	if action.IsExpired() {
 		// Expired and no cancel reaction, close issue
-		return d.executeClose(ctx, action)
+		return d.executeClose(ctx, action, issue, nil)
 	}
 
 	return nil // Not expired yet
 }
 
-// executeClose performs the actual close
-func (d *DuplicateChecker) executeClose(ctx context.Context, action *pending.PendingAction) error {
+// ApprovePendingClose closes an issue with a pending close without waiting
+// for it to expire. approvedBy is recorded when users approved it explicitly.
+func (d *DuplicateChecker) ApprovePendingClose(ctx context.Context, action *pending.PendingAction, approvedBy []string) error {
+	if d.pendingManager == nil || d.cfg == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
 	if d.dryRun {
 		return nil
 	}
 
-	// Add duplicate label
-	if err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"duplicate"}); err != nil {
+	if err := d.pendingManager.Validate(ctx, action); err != nil {
 		return err
 	}
 
-	// Close issue
-	if err := d.gh.CloseIssue(ctx, action.Org, action.Repo, action.IssueNumber, "not_planned"); err != nil {
+	if len(approvedBy) > 0 {
+		if err := d.pendingManager.Record(ctx, action, pending.StateDecided, approvedBy); err != nil {
+			return err
+		}
+	}
+
+	issue, err := d.gh.GetIssue(ctx, action.Org, action.Repo, action.IssueNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get issue: %w", err)
+	}
+	return d.executeClose(ctx, action, issue, approvedBy)
+}
+
+// CancelPendingClose cancels a pending close on behalf of the users who
+// decided and marks the issue as a potential duplicate instead
+func (d *DuplicateChecker) CancelPendingClose(ctx context.Context, action *pending.PendingAction, decidedBy []string) error {
+	if d.pendingManager == nil {
+		return fmt.Errorf("delayed actions not configured")
+	}
+	if d.dryRun {
+		return nil
+	}
+
+	if err := d.pendingManager.Record(ctx, action, pending.StateDecided, decidedBy); err != nil {
+		return err
+	}
+	if err := d.pendingManager.Cancel(ctx, action); err != nil {
+		return err
+	}
+	if err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"potential-duplicate"}); err != nil {
+		return err
+	}
+	cancelComment := formatCloseCancelledComment(decidedBy)
+	return d.gh.PostComment(ctx, action.Org, action.Repo, action.IssueNumber, cancelComment)
+}
+
+// executeClose performs the actual close and audits it. approvedBy lists
+// the users who asked for it, if any.
+func (d *DuplicateChecker) executeClose(ctx context.Context, action *pending.PendingAction, issue *models.Issue, approvedBy []string) error {
+	if d.dryRun {
+		return nil
+	}
+
+	// Add duplicate label, then close issue and link it to the original
+	err := d.gh.AddLabels(ctx, action.Org, action.Repo, action.IssueNumber, []string{"duplicate"})
+	if err == nil {
+		err = CloseAsDuplicate(ctx, d.gh, d.cfg, issue, action.Target)
+	}
+	d.audit.Record(audit.Entry{
+		Org:     action.Org,
+		Repo:    action.Repo,
+		Issue:   action.IssueNumber,
+		Action:  "close_duplicate",
+		Target:  action.Target,
+		Actor:   audit.Actor(approvedBy),
+		Outcome: audit.OutcomeOf(err, audit.OutcomeExecuted),
+		Error:   audit.ErrorText(err),
+	})
+	if err != nil {
 		return err
 	}
 
 	// Remove pending label
-	if err := d.pendingManager.Cancel(ctx, action); err != nil {
+	if err := d.pendingManager.Complete(ctx, action); err != nil {
 		fmt.Printf("Warning: failed to remove pending-close label from %s/%s#%d: %v\n", action.Org, action.Repo, action.IssueNumber, err)
 	}
 
@@ -307,7 +391,7 @@ func (d *DuplicateChecker) executeClose(ctx context.Context, action *pending.Pen
 func (d *DuplicateChecker) formatDelayedCloseComment(result *DuplicateResult, expiresAt time.Time, cfg config.DelayedActionsConfig, action *pending.PendingAction) (string, error) {
 	deadline := expiresAt.Format("2006-01-02 15:04 MST")
 
-	metadata, err := pending.FormatPendingActionMetadata(action)
+	metadata, err := pending.FormatPendingActionMetadata(action, cfg.SigningSecret)
 	if err != nil {
 		return "", err
 	}
@@ -315,12 +399,12 @@ func (d *DuplicateChecker) formatDelayedCloseComment(result *DuplicateResult, ex
 	return fmt.Sprintf(`⚠️ **This issue will be closed as a duplicate in %d hours**
 
 **Original issue:** [#%d - %s](%s)
-**Similarity:** %.0f%%
+**Similarity:** %.0f%%%s
 
 **React to this comment:**
 - 👍 (%s) to approve and proceed with closing
 - 👎 (%s) to cancel and add potential-duplicate label instead
-
+%s
 **Deadline**: %s
 
 If no reaction is provided, the issue will be closed automatically.
@@ -334,16 +418,26 @@ If no reaction is provided, the issue will be closed automatically.
 		result.Original.Title,
 		result.Original.URL,
 		result.Similarity*100,
+		formatRationale(result.Rationale),
 		cfg.ApproveReaction,
 		cfg.CancelReaction,
+		pending.FormatAuthorization(cfg.Authorization),
 		deadline,
 		metadata,
 	), nil
 }
 
+// formatRationale renders the LLM rationale as an extra comment line
+func formatRationale(rationale string) string {
+	if rationale == "" {
+		return ""
+	}
+	return "\n**Why:** " + rationale
+}
+
 // formatCloseCancelledComment creates a cancellation comment
-func formatCloseCancelledComment() string {
-	return `✅ Auto-close has been cancelled based on your reaction.
+func formatCloseCancelledComment(decidedBy []string) string {
+	return `✅ Auto-close has been cancelled by ` + pending.FormatDecidedBy(decidedBy) + `.
 
 The issue will remain open and has been labeled as ` + "`potential-duplicate`" + ` for maintainer review.
 
@@ -0,0 +1,209 @@
+package triage
+
+import (
+	"context"
+	"log"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// LabelLister fetches the labels defined in a repository
+type LabelLister interface {
+	ListRepoLabels(ctx context.Context, org, repo string) ([]github.Label, error)
+}
+
+// SyncLabels makes the classifier merge each repository's own labels, read
+// through source, into the configured ones
+func (c *Classifier) SyncLabels(source LabelLister) {
+	c.labelSource = source
+}
+
+// Labels returns the labels that may be suggested for issues in org/repo
+func (c *Classifier) Labels(ctx context.Context, org, repo string) []config.LabelConfig {
+	return c.labelsFor(ctx, &models.Issue{Org: org, Repo: repo})
+}
+
+// labelsFor returns the labels that may be suggested for an issue: the
+// configured labels, merged with the repository's own labels when syncing is
+// enabled, and filtered by the allow/deny patterns
+func (c *Classifier) labelsFor(ctx context.Context, issue *models.Issue) []config.LabelConfig {
+	labels := c.labels
+
+	if c.labelSource != nil {
+		key := issue.Org + "/" + issue.Repo
+		synced, ok := c.repoLabels[key]
+		if !ok {
+			repoLabels, err := c.labelSource.ListRepoLabels(ctx, issue.Org, issue.Repo)
+			if err != nil {
+				// Fall back to the configured labels; they are still filtered below
+				log.Printf("Warning: failed to sync labels for %s: %v", key, err)
+			} else {
+				synced = mergeLabels(c.labels, repoLabels)
+				c.repoLabels[key] = synced
+				ok = true
+			}
+		}
+		if ok {
+			labels = synced
+		}
+	}
+
+	var allowed []config.LabelConfig
+	for _, l := range labels {
+		if labelAllowed(l.Name, c.allow, c.deny) {
+			allowed = append(allowed, l)
+		}
+	}
+	return allowed
+}
+
+// mergeLabels combines configured labels with the labels that exist in the
+// repository. Only labels that exist are kept, under the repository's
+// spelling; configured keywords and descriptions take precedence over the
+// repository's description.
+func mergeLabels(configured []config.LabelConfig, repoLabels []github.Label) []config.LabelConfig {
+	byName := make(map[string]config.LabelConfig, len(configured))
+	for _, l := range configured {
+		byName[strings.ToLower(l.Name)] = l
+	}
+
+	merged := make([]config.LabelConfig, 0, len(repoLabels))
+	for _, rl := range repoLabels {
+		label := config.LabelConfig{
+			Name:        rl.Name,
+			Description: rl.Description,
+		}
+		if cfgLabel, ok := byName[strings.ToLower(rl.Name)]; ok {
+			label.Keywords = cfgLabel.Keywords
+			label.Rules = cfgLabel.Rules
+			if cfgLabel.Description != "" {
+				label.Description = cfgLabel.Description
+			}
+		}
+		merged = append(merged, label)
+	}
+
+	return merged
+}
+
+// labelAllowed reports whether a label may be suggested. An empty allow list
+// allows every label; deny patterns always win. Patterns use path.Match
+// syntax and are matched case-insensitively.
+func labelAllowed(name string, allow, deny []string) bool {
+	name = strings.ToLower(name)
+
+	for _, pattern := range deny {
+		if matchLabel(pattern, name) {
+			return false
+		}
+	}
+
+	if len(allow) == 0 {
+		return true
+	}
+	for _, pattern := range allow {
+		if matchLabel(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabel matches a lowercased label name against a pattern
+func matchLabel(pattern, name string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), name)
+	return err == nil && ok
+}
+
+// groupOf returns the index of the exclusive group containing a label, or -1
+func groupOf(name string, groups []config.LabelGroupConfig) int {
+	name = strings.ToLower(name)
+	for i, g := range groups {
+		if !g.Exclusive {
+			continue
+		}
+		for _, pattern := range g.Labels {
+			if matchLabel(pattern, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// selectExclusive keeps only the highest-confidence label of each exclusive
+// group. Results are returned by descending confidence.
+func selectExclusive(results []LabelResult, groups []config.LabelGroupConfig) []LabelResult {
+	sorted := make([]LabelResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Confidence != sorted[j].Confidence {
+			return sorted[i].Confidence > sorted[j].Confidence
+		}
+		return sorted[i].Label < sorted[j].Label
+	})
+
+	taken := make(map[int]bool)
+	kept := make([]LabelResult, 0, len(sorted))
+	for _, r := range sorted {
+		g := groupOf(r.Label, groups)
+		if g >= 0 {
+			if taken[g] {
+				continue
+			}
+			taken[g] = true
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
+// Conflicts returns the labels already on an issue that share an exclusive
+// group with a suggested label and so should be removed
+func (c *Classifier) Conflicts(existing []string, results []LabelResult) []string {
+	suggested := make(map[int]string)
+	for _, r := range results {
+		if g := groupOf(r.Label, c.groups); g >= 0 {
+			suggested[g] = r.Label
+		}
+	}
+
+	var conflicts []string
+	for _, l := range existing {
+		g := groupOf(l, c.groups)
+		if g < 0 {
+			continue
+		}
+		if keep, ok := suggested[g]; ok && !strings.EqualFold(keep, l) {
+			conflicts = append(conflicts, l)
+		}
+	}
+	return conflicts
+}
+
+// formatExclusiveGroups tells the LLM which candidate labels exclude each other
+func formatExclusiveGroups(labels []string, groups []config.LabelGroupConfig) string {
+	members := make(map[int][]string)
+	for _, l := range labels {
+		if g := groupOf(l, groups); g >= 0 {
+			members[g] = append(members[g], l)
+		}
+	}
+
+	var sb strings.Builder
+	for i := range groups {
+		if len(members[i]) < 2 {
+			continue
+		}
+		if sb.Len() == 0 {
+			sb.WriteString("\nPick at most one label from each of these groups:\n")
+		}
+		sb.WriteString("- " + strings.Join(members[i], ", ") + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package triage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/internal/github"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+type staticLabels []github.Label
+
+func (s staticLabels) ListRepoLabels(ctx context.Context, org, repo string) ([]github.Label, error) {
+	return s, nil
+}
+
+func TestMergeLabels(t *testing.T) {
+	configured := []config.LabelConfig{
+		{Name: "bug", Keywords: []string{"crash"}},
+		{Name: "docs", Description: "Documentation changes", Keywords: []string{"readme"}},
+		{Name: "missing", Keywords: []string{"never"}},
+	}
+	repoLabels := []github.Label{
+		{Name: "Bug", Description: "Something isn't working"},
+		{Name: "docs", Description: "Docs"},
+		{Name: "area/cli", Description: "Command line interface"},
+	}
+
+	got := mergeLabels(configured, repoLabels)
+	want := []config.LabelConfig{
+		{Name: "Bug", Description: "Something isn't working", Keywords: []string{"crash"}},
+		{Name: "docs", Description: "Documentation changes", Keywords: []string{"readme"}},
+		{Name: "area/cli", Description: "Command line interface"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeLabels() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLabelAllowed(t *testing.T) {
+	tests := []struct {
+		name  string
+		label string
+		allow []string
+		deny  []string
+		want  bool
+	}{
+		{"no patterns", "bug", nil, nil, true},
+		{"allowed area", "area/cli", []string{"area/*"}, nil, true},
+		{"not in allow list", "bug", []string{"area/*"}, nil, false},
+		{"denied priority", "priority/high", nil, []string{"priority/*"}, false},
+		{"deny wins", "priority/high", []string{"*/*"}, []string{"priority/*"}, false},
+		{"case insensitive", "Priority/High", nil, []string{"priority/*"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := labelAllowed(tt.label, tt.allow, tt.deny); got != tt.want {
+				t.Errorf("labelAllowed(%q) = %v, want %v", tt.label, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifier_SyncedLabels(t *testing.T) {
+	c := NewClassifier(nil, &config.ClassifierConfig{
+		Labels: []config.LabelConfig{{Name: "bug", Keywords: []string{"crash"}}},
+		Deny:   []string{"priority/*"},
+	})
+	c.labelSource = staticLabels{{Name: "bug"}, {Name: "area/cli"}, {Name: "priority/high"}}
+
+	issue := &models.Issue{Org: "o", Repo: "r", Title: "crash"}
+	var names []string
+	for _, l := range c.labelsFor(context.Background(), issue) {
+		names = append(names, l.Name)
+	}
+
+	if want := []string{"bug", "area/cli"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("labelsFor() = %v, want %v", names, want)
+	}
+}
+
+func TestSelectExclusive(t *testing.T) {
+	groups := []config.LabelGroupConfig{
+		{Name: "priority", Exclusive: true, Labels: []string{"priority/*"}},
+		{Name: "area", Labels: []string{"area/*"}},
+	}
+	results := []LabelResult{
+		{Label: "priority/p2", Confidence: 0.7},
+		{Label: "area/cli", Confidence: 0.8},
+		{Label: "priority/p0", Confidence: 0.9},
+		{Label: "area/api", Confidence: 0.75},
+	}
+
+	var got []string
+	for _, r := range selectExclusive(results, groups) {
+		got = append(got, r.Label)
+	}
+
+	if want := []string{"priority/p0", "area/cli", "area/api"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("selectExclusive() = %v, want %v", got, want)
+	}
+}
+
+func TestClassifier_Conflicts(t *testing.T) {
+	c := NewClassifier(nil, &config.ClassifierConfig{
+		Groups: []config.LabelGroupConfig{
+			{Name: "type", Exclusive: true, Labels: []string{"type/bug", "type/feature", "type/question"}},
+			{Name: "priority", Exclusive: true, Labels: []string{"priority/*"}},
+		},
+	})
+
+	existing := []string{"type/question", "priority/p1", "area/cli"}
+	results := []LabelResult{{Label: "type/bug", Confidence: 0.9}}
+
+	got := c.Conflicts(existing, results)
+	if want := []string{"type/question"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Conflicts() = %v, want %v", got, want)
+	}
+}
@@ -17,6 +17,57 @@ import (
 	"github.com/Kavirubc/gh-simili/pkg/models"
 )
 
+// IssueRouter suggests which repository an issue belongs in
+type IssueRouter interface {
+	Route(ctx context.Context, issue *models.Issue) (*RoutingResult, error)
+}
+
+// NewIssueRouter creates the router for the configured strategy, or nil when
+// routing is disabled or the strategy's dependency is missing. scorer may be nil.
+func NewIssueRouter(cfg *config.Config, provider llm.Provider, scorer RepoScorer) IssueRouter {
+	routerCfg := &cfg.Triage.Router
+	if !routerCfg.Enabled {
+		return nil
+	}
+
+	switch routerCfg.Strategy {
+	case "semantic":
+		if scorer == nil {
+			return nil
+		}
+		return NewSemanticRouter(scorer, cfg.Repositories, routerCfg)
+	default:
+		if provider == nil {
+			return nil
+		}
+		return NewRouter(provider, cfg.Repositories)
+	}
+}
+
+// SuggestedTarget returns the repository a routing result moves the issue to,
+// or "" when it keeps the issue, falls below the repo's router threshold or
+// names a repository that is not configured
+func SuggestedTarget(cfg *config.Config, issue *models.Issue, result *RoutingResult) string {
+	if result == nil || result.Confidence < cfg.GetRouterThreshold(issue.Org, issue.Repo) {
+		return ""
+	}
+
+	// Ensure we don't route to the same repo
+	if strings.EqualFold(result.TargetRepo, issue.FullRepo()) {
+		return ""
+	}
+
+	// Validate that target repo actually exists in config
+	for _, r := range cfg.Repositories {
+		if strings.EqualFold(r.Org+"/"+r.Repo, result.TargetRepo) {
+			return r.Org + "/" + r.Repo
+		}
+	}
+
+	log.Printf("Warning: Router suggested unknown target repository: %s", result.TargetRepo)
+	return ""
+}
+
 // Router handles AI-based issue routing decisions
 type Router struct {
 	llm          llm.Provider
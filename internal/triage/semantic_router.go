@@ -0,0 +1,84 @@
+package triage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// RepoScorer scores how closely an issue resembles each repository's indexed issues
+type RepoScorer interface {
+	RepoScores(ctx context.Context, issue *models.Issue, repos []string, method string, neighbors int) (map[string]float64, error)
+}
+
+// SemanticRouter routes issues to the repository whose indexed issues they
+// most resemble. It needs no LLM, only the vector index.
+type SemanticRouter struct {
+	scorer       RepoScorer
+	repositories []config.RepositoryConfig
+	cfg          *config.RouterConfig
+}
+
+// NewSemanticRouter creates a new semantic issue router
+func NewSemanticRouter(scorer RepoScorer, repos []config.RepositoryConfig, cfg *config.RouterConfig) *SemanticRouter {
+	return &SemanticRouter{
+		scorer:       scorer,
+		repositories: repos,
+		cfg:          cfg,
+	}
+}
+
+// Route suggests the repository an issue belongs in. The current repository
+// is kept unless another scores higher by at least the configured margin.
+func (r *SemanticRouter) Route(ctx context.Context, issue *models.Issue) (*RoutingResult, error) {
+	current := strings.ToLower(issue.FullRepo())
+
+	candidates := []string{issue.FullRepo()}
+	for _, repo := range r.repositories {
+		fullRepo := repo.Org + "/" + repo.Repo
+		if repo.Enabled && strings.ToLower(fullRepo) != current {
+			candidates = append(candidates, fullRepo)
+		}
+	}
+	if len(candidates) < 2 {
+		return nil, nil // Nowhere else to route
+	}
+
+	scores, err := r.scorer.RepoScores(ctx, issue, candidates, r.cfg.Method, r.cfg.Neighbors)
+	if err != nil {
+		return nil, fmt.Errorf("semantic routing failed: %w", err)
+	}
+
+	return pickRoute(issue.FullRepo(), candidates, scores, r.cfg.Margin, r.cfg.Method), nil
+}
+
+// pickRoute chooses the best scoring candidate, keeping the current repository
+// unless the best one beats it by at least margin
+func pickRoute(current string, candidates []string, scores map[string]float64, margin float64, method string) *RoutingResult {
+	currentScore := scores[strings.ToLower(current)]
+
+	best := current
+	bestScore := currentScore
+	for _, c := range candidates {
+		if s := scores[strings.ToLower(c)]; s > bestScore {
+			best, bestScore = c, s
+		}
+	}
+
+	if strings.EqualFold(best, current) || bestScore-currentScore < margin {
+		return &RoutingResult{
+			TargetRepo: current,
+			Confidence: currentScore,
+			Reason:     fmt.Sprintf("no repository resembles this issue more than %s by the %.2f margin (%s)", current, margin, method),
+		}
+	}
+
+	return &RoutingResult{
+		TargetRepo: best,
+		Confidence: bestScore,
+		Reason:     fmt.Sprintf("closest to %s issues (%s score %.2f vs %.2f for %s)", best, method, bestScore, currentScore, current),
+	}
+}
@@ -0,0 +1,49 @@
+package triage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/internal/config"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+type staticScores map[string]float64
+
+func (s staticScores) RepoScores(ctx context.Context, issue *models.Issue, repos []string, method string, neighbors int) (map[string]float64, error) {
+	return s, nil
+}
+
+func TestSemanticRouter_Route(t *testing.T) {
+	repos := []config.RepositoryConfig{
+		{Org: "org", Repo: "app", Enabled: true},
+		{Org: "org", Repo: "docs", Enabled: true},
+		{Org: "org", Repo: "archived", Enabled: false},
+	}
+	cfg := &config.RouterConfig{Method: "centroid", Margin: 0.05}
+	issue := &models.Issue{Org: "org", Repo: "app", Title: "Typo in guide"}
+
+	tests := []struct {
+		name   string
+		scores staticScores
+		want   string
+	}{
+		{"clear winner", staticScores{"org/app": 0.60, "org/docs": 0.72}, "org/docs"},
+		{"within margin", staticScores{"org/app": 0.70, "org/docs": 0.73}, "org/app"},
+		{"current is best", staticScores{"org/app": 0.80, "org/docs": 0.60}, "org/app"},
+		{"disabled repo ignored", staticScores{"org/app": 0.50, "org/archived": 0.90}, "org/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := NewSemanticRouter(tt.scores, repos, cfg)
+			result, err := router.Route(context.Background(), issue)
+			if err != nil {
+				t.Fatalf("Route() error = %v", err)
+			}
+			if result.TargetRepo != tt.want {
+				t.Errorf("Route() target = %s, want %s (%s)", result.TargetRepo, tt.want, result.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package triage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Kavirubc/gh-simili/internal/llm"
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+// Verdicts returned by duplicate verification
+const (
+	VerdictDuplicate    = "duplicate"
+	VerdictNotDuplicate = "not_duplicate"
+	VerdictUncertain    = "uncertain"
+)
+
+// IssueFetcher loads full issue details (vector payloads carry no body)
+type IssueFetcher interface {
+	GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error)
+}
+
+// DuplicateVerifier asks the LLM to confirm a similarity-based duplicate match
+type DuplicateVerifier struct {
+	llm llm.Provider
+	gh  IssueFetcher
+}
+
+// verification is the LLM's answer
+type verification struct {
+	Verdict   string `json:"verdict"`
+	Rationale string `json:"rationale"`
+}
+
+// NewDuplicateVerifier creates a new duplicate verifier. gh may be nil, in
+// which case only the information already on the match is compared.
+func NewDuplicateVerifier(provider llm.Provider, gh IssueFetcher) *DuplicateVerifier {
+	return &DuplicateVerifier{
+		llm: provider,
+		gh:  gh,
+	}
+}
+
+// Verify records the LLM verdict on result. Auto-close is only kept when the
+// verdict is "duplicate"; a "not_duplicate" verdict clears the match entirely.
+// Any failure leaves the match in place but disables auto-close.
+func (v *DuplicateVerifier) Verify(ctx context.Context, issue *models.Issue, result *DuplicateResult) {
+	if result == nil || !result.IsDuplicate || result.Original == nil {
+		return
+	}
+
+	// Load the body into a copy: the original is shared with the similarity results
+	original := *result.Original
+	if original.Body == "" && v.gh != nil {
+		full, err := v.gh.GetIssue(ctx, original.Org, original.Repo, original.Number)
+		if err != nil {
+			log.Printf("Warning: failed to load original issue #%d for verification: %v", original.Number, err)
+		} else {
+			original.Body = truncateText(full.Body, 2000)
+		}
+	}
+
+	verdict, err := v.ask(ctx, issue, &original)
+	if err != nil {
+		log.Printf("Warning: duplicate verification failed: %v", err)
+		result.Verdict = VerdictUncertain
+		result.ShouldClose = false
+		return
+	}
+
+	result.Verdict = verdict.Verdict
+	result.Rationale = verdict.Rationale
+
+	switch verdict.Verdict {
+	case VerdictDuplicate:
+		// Score and verdict agree; keep ShouldClose as decided by the threshold
+	case VerdictNotDuplicate:
+		result.IsDuplicate = false
+		result.ShouldClose = false
+	default:
+		result.Verdict = VerdictUncertain
+		result.ShouldClose = false
+	}
+}
+
+// ask sends both issues to the LLM
+func (v *DuplicateVerifier) ask(ctx context.Context, issue, original *models.Issue) (*verification, error) {
+	system := `You are a GitHub issue triage assistant deciding whether a new issue is a duplicate of an existing one.
+Two issues are duplicates only if they report the same underlying problem or request, not merely the same area.
+Respond with a JSON object containing:
+- "verdict": "duplicate", "not_duplicate" or "uncertain"
+- "rationale": one or two sentences explaining the decision, suitable for showing to the issue author`
+
+	prompt := fmt.Sprintf(`New Issue Title: %s
+
+New Issue Body:
+%s
+
+Existing Issue #%d Title: %s
+
+Existing Issue Body:
+%s
+
+Is the new issue a duplicate of the existing one? Return JSON only.`,
+		issue.Title,
+		truncateText(issue.Body, 2000),
+		original.Number,
+		original.Title,
+		truncateText(original.Body, 2000))
+
+	response, err := v.llm.CompleteWithSystem(ctx, system, prompt)
+	if err != nil {
+		return nil, fmt.Errorf("LLM verification failed: %w", err)
+	}
+
+	response = strings.TrimSpace(response)
+	response = strings.TrimPrefix(response, "```json")
+	response = strings.TrimPrefix(response, "```")
+	response = strings.TrimSuffix(response, "```")
+	response = strings.TrimSpace(response)
+
+	var result verification
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse LLM response: %w", err)
+	}
+	result.Verdict = strings.ToLower(strings.TrimSpace(result.Verdict))
+
+	return &result, nil
+}
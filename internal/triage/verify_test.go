@@ -0,0 +1,98 @@
+package triage
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestDuplicateVerifier_Verify(t *testing.T) {
+	issue := &models.Issue{Title: "Crash on startup", Body: "panic: nil map"}
+
+	tests := []struct {
+		name            string
+		llm             *mockLLM
+		wantDuplicate   bool
+		wantShouldClose bool
+		wantVerdict     string
+	}{
+		{
+			name:            "confirmed duplicate keeps auto-close",
+			llm:             &mockLLM{response: "```json\n{\"verdict\": \"duplicate\", \"rationale\": \"Same nil map panic.\"}\n```"},
+			wantDuplicate:   true,
+			wantShouldClose: true,
+			wantVerdict:     VerdictDuplicate,
+		},
+		{
+			name:            "rejected duplicate clears match",
+			llm:             &mockLLM{response: `{"verdict": "not_duplicate", "rationale": "Different component."}`},
+			wantDuplicate:   false,
+			wantShouldClose: false,
+			wantVerdict:     VerdictNotDuplicate,
+		},
+		{
+			name:            "uncertain disables auto-close",
+			llm:             &mockLLM{response: `{"verdict": "maybe", "rationale": ""}`},
+			wantDuplicate:   true,
+			wantShouldClose: false,
+			wantVerdict:     VerdictUncertain,
+		},
+		{
+			name:            "LLM error disables auto-close",
+			llm:             &mockLLM{err: errors.New("timeout")},
+			wantDuplicate:   true,
+			wantShouldClose: false,
+			wantVerdict:     VerdictUncertain,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &DuplicateResult{
+				IsDuplicate: true,
+				Similarity:  0.97,
+				Original:    &models.Issue{Number: 1, Title: "Startup panic", Body: "nil map"},
+				ShouldClose: true,
+			}
+
+			NewDuplicateVerifier(tt.llm, nil).Verify(context.Background(), issue, result)
+
+			if result.IsDuplicate != tt.wantDuplicate {
+				t.Errorf("IsDuplicate = %v, want %v", result.IsDuplicate, tt.wantDuplicate)
+			}
+			if result.ShouldClose != tt.wantShouldClose {
+				t.Errorf("ShouldClose = %v, want %v", result.ShouldClose, tt.wantShouldClose)
+			}
+			if result.Verdict != tt.wantVerdict {
+				t.Errorf("Verdict = %q, want %q", result.Verdict, tt.wantVerdict)
+			}
+		})
+	}
+}
+
+// bodyFetcher returns a fixed body for every issue
+type bodyFetcher struct {
+	body string
+}
+
+func (f *bodyFetcher) GetIssue(ctx context.Context, org, repo string, number int) (*models.Issue, error) {
+	return &models.Issue{Org: org, Repo: repo, Number: number, Body: f.body}, nil
+}
+
+func TestDuplicateVerifier_Verify_KeepsOriginal(t *testing.T) {
+	issue := &models.Issue{Title: "Crash on startup", Body: "panic: nil map"}
+	original := &models.Issue{Number: 1, Title: "Startup panic"}
+	result := &DuplicateResult{IsDuplicate: true, Original: original, ShouldClose: true}
+
+	llm := &mockLLM{response: `{"verdict": "duplicate", "rationale": "Same panic."}`}
+	NewDuplicateVerifier(llm, &bodyFetcher{body: "panic: nil map in loader"}).Verify(context.Background(), issue, result)
+
+	if original.Body != "" {
+		t.Errorf("Original.Body = %q, want the shared original left unchanged", original.Body)
+	}
+	if result.Verdict != VerdictDuplicate {
+		t.Errorf("Verdict = %q, want %q", result.Verdict, VerdictDuplicate)
+	}
+}
@@ -0,0 +1,127 @@
+package vectordb
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// Centroid is the mean of a repository's indexed issue vectors. Each
+// issue's share is kept so the issue being routed can be left out.
+type Centroid struct {
+	sum    []float64
+	count  int
+	issues map[int]*issueVectors
+}
+
+// issueVectors is one issue's contribution to a centroid
+type issueVectors struct {
+	sum   []float64
+	count int
+}
+
+// RepoCentroid returns the centroid of a repository's indexed issues, or nil
+// when nothing is indexed
+func (c *Client) RepoCentroid(ctx context.Context, collection, org, repo string) (*Centroid, error) {
+	points, err := c.scrollPoints(ctx, collection, &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", org),
+			qdrant.NewMatchKeyword("repo", repo),
+		},
+		MustNot: []*qdrant.Condition{qdrant.NewMatchKeyword("kind", KindComment)},
+	}, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repo vectors: %w", err)
+	}
+
+	centroid := &Centroid{issues: make(map[int]*issueVectors)}
+	for _, p := range points {
+		number := int(p.GetPayload()["number"].GetIntegerValue())
+		centroid.add(number, p.GetVectors().GetVector().GetData())
+	}
+	if centroid.count == 0 {
+		return nil, nil
+	}
+	return centroid, nil
+}
+
+// add includes an issue vector in the centroid
+func (c *Centroid) add(number int, data []float32) {
+	if len(data) == 0 {
+		return
+	}
+	if c.sum == nil {
+		c.sum = make([]float64, len(data))
+	}
+	if len(data) != len(c.sum) {
+		return
+	}
+
+	iv, ok := c.issues[number]
+	if !ok {
+		iv = &issueVectors{sum: make([]float64, len(data))}
+		c.issues[number] = iv
+	}
+	for i, v := range data {
+		c.sum[i] += float64(v)
+		iv.sum[i] += float64(v)
+	}
+	c.count++
+	iv.count++
+}
+
+// Vector returns the normalized mean vector without the vectors of issue
+// number exclude (0 excludes nothing), or nil when no other vectors remain
+func (c *Centroid) Vector(exclude int) []float32 {
+	iv, ok := c.issues[exclude]
+	if !ok || exclude == 0 {
+		return normalize(c.sum)
+	}
+	if c.count == iv.count {
+		return nil
+	}
+
+	sum := make([]float64, len(c.sum))
+	for i := range c.sum {
+		sum[i] = c.sum[i] - iv.sum[i]
+	}
+	return normalize(sum)
+}
+
+// normalize scales a vector to unit length
+func normalize(v []float64) []float32 {
+	var norm float64
+	for _, x := range v {
+		norm += x * x
+	}
+	norm = math.Sqrt(norm)
+
+	out := make([]float32, len(v))
+	if norm == 0 {
+		return out
+	}
+	for i, x := range v {
+		out[i] = float32(x / norm)
+	}
+	return out
+}
+
+// Cosine returns the cosine similarity of two vectors
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,43 @@
+package vectordb
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCentroid_Vector(t *testing.T) {
+	c := &Centroid{issues: make(map[int]*issueVectors)}
+	c.add(1, []float32{1, 0})
+	c.add(2, []float32{0, 1})
+
+	tests := []struct {
+		name    string
+		exclude int
+		want    []float32
+	}{
+		{name: "all issues", exclude: 0, want: []float32{float32(math.Sqrt2 / 2), float32(math.Sqrt2 / 2)}},
+		{name: "without issue 2", exclude: 2, want: []float32{1, 0}},
+		{name: "unknown issue", exclude: 3, want: []float32{float32(math.Sqrt2 / 2), float32(math.Sqrt2 / 2)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := c.Vector(tt.exclude)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Vector() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(float64(got[i]-tt.want[i])) > 1e-6 {
+					t.Errorf("Vector() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	only := &Centroid{issues: make(map[int]*issueVectors)}
+	only.add(1, []float32{1, 0})
+	if got := only.Vector(1); got != nil {
+		t.Errorf("Vector() without the only issue = %v, want nil", got)
+	}
+}
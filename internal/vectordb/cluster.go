@@ -0,0 +1,169 @@
+package vectordb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+	"github.com/qdrant/go-client/qdrant"
+)
+
+// scrollPageSize is the number of points fetched per scroll request
+const scrollPageSize = 256
+
+// SetCluster records the duplicate cluster of an issue on all of its points
+func (c *Client) SetCluster(ctx context.Context, collection, org, repo string, number int, clusterID string) error {
+	_, err := c.qdrant.SetPayload(ctx, &qdrant.SetPayloadPoints{
+		CollectionName: collection,
+		Payload: map[string]*qdrant.Value{
+			"cluster_id": qdrant.NewValueString(clusterID),
+		},
+		PointsSelector: qdrant.NewPointsSelectorFilter(&qdrant.Filter{
+			Must: []*qdrant.Condition{
+				qdrant.NewMatchKeyword("org", org),
+				qdrant.NewMatchKeyword("repo", repo),
+				qdrant.NewMatchInt("number", int64(number)),
+			},
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cluster: %w", err)
+	}
+	return nil
+}
+
+// ClusterMembers returns every issue in a duplicate cluster
+func (c *Client) ClusterMembers(ctx context.Context, collection, clusterID string) ([]models.Issue, error) {
+	points, err := c.scrollAll(ctx, collection, &qdrant.Filter{
+		Must:    []*qdrant.Condition{qdrant.NewMatchKeyword("cluster_id", clusterID)},
+		MustNot: []*qdrant.Condition{qdrant.NewMatchKeyword("kind", KindComment)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster members: %w", err)
+	}
+
+	issues := make([]models.Issue, len(points))
+	for i, p := range points {
+		issues[i] = payloadToIssue(p.Payload)
+	}
+	return issues, nil
+}
+
+// ClusterIDs returns the stored cluster of each issue that has one, keyed by issue UUID
+func (c *Client) ClusterIDs(ctx context.Context, collection string, issues []*models.Issue) (map[string]string, error) {
+	ids := make([]*qdrant.PointId, len(issues))
+	for i, issue := range issues {
+		ids[i] = qdrant.NewIDUUID(issue.UUID())
+	}
+
+	points, err := c.qdrant.Get(ctx, &qdrant.GetPoints{
+		CollectionName: collection,
+		Ids:            ids,
+		WithPayload:    qdrant.NewWithPayloadInclude("cluster_id"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster ids: %w", err)
+	}
+
+	clusters := make(map[string]string, len(points))
+	for _, p := range points {
+		if v := p.Payload["cluster_id"]; v != nil && v.GetStringValue() != "" {
+			clusters[p.Id.GetUuid()] = v.GetStringValue()
+		}
+	}
+	return clusters, nil
+}
+
+// RepoIssues returns every indexed issue of a repository
+func (c *Client) RepoIssues(ctx context.Context, collection, org, repo string) ([]models.Issue, error) {
+	points, err := c.scrollAll(ctx, collection, &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", org),
+			qdrant.NewMatchKeyword("repo", repo),
+		},
+		MustNot: []*qdrant.Condition{qdrant.NewMatchKeyword("kind", KindComment)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repo issues: %w", err)
+	}
+
+	issues := make([]models.Issue, len(points))
+	for i, p := range points {
+		issues[i] = payloadToIssue(p.Payload)
+	}
+	return issues, nil
+}
+
+// SearchByIssue finds issues similar to an already indexed issue, excluding itself
+func (c *Client) SearchByIssue(ctx context.Context, collection string, issue *models.Issue, limit int, threshold float64) ([]SearchResult, error) {
+	scoreThreshold := float32(threshold)
+
+	points, err := c.qdrant.Query(ctx, &qdrant.QueryPoints{
+		CollectionName: collection,
+		Query:          qdrant.NewQueryID(qdrant.NewIDUUID(issue.UUID())),
+		Limit:          qdrant.PtrOf(uint64(limit * 2)),
+		ScoreThreshold: &scoreThreshold,
+		WithPayload:    qdrant.NewWithPayload(true),
+		Filter: &qdrant.Filter{
+			MustNot: []*qdrant.Condition{
+				qdrant.NewFilterAsCondition(&qdrant.Filter{
+					Must: []*qdrant.Condition{
+						qdrant.NewMatchKeyword("org", issue.Org),
+						qdrant.NewMatchKeyword("repo", issue.Repo),
+						qdrant.NewMatchInt("number", int64(issue.Number)),
+					},
+				}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search by issue failed: %w", err)
+	}
+
+	results := make([]SearchResult, 0, len(points))
+	for _, point := range points {
+		results = append(results, SearchResult{
+			Issue: payloadToIssue(point.Payload),
+			Score: float64(point.Score),
+		})
+	}
+	results = dedupeByIssue(results)
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// scrollAll pages through every point matching a filter
+func (c *Client) scrollAll(ctx context.Context, collection string, filter *qdrant.Filter) ([]*qdrant.RetrievedPoint, error) {
+	return c.scrollPoints(ctx, collection, filter, false)
+}
+
+// scrollPoints pages through every point matching a filter, optionally with vectors
+func (c *Client) scrollPoints(ctx context.Context, collection string, filter *qdrant.Filter, withVectors bool) ([]*qdrant.RetrievedPoint, error) {
+	var all []*qdrant.RetrievedPoint
+	var offset *qdrant.PointId
+
+	for {
+		// Fetch one extra point to use as the next page offset
+		points, err := c.qdrant.Scroll(ctx, &qdrant.ScrollPoints{
+			CollectionName: collection,
+			Filter:         filter,
+			Offset:         offset,
+			Limit:          qdrant.PtrOf(uint32(scrollPageSize + 1)),
+			WithPayload:    qdrant.NewWithPayload(true),
+			WithVectors:    qdrant.NewWithVectors(withVectors),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(points) <= scrollPageSize {
+			return append(all, points...), nil
+		}
+
+		all = append(all, points[:scrollPageSize]...)
+		offset = points[scrollPageSize].Id
+	}
+}
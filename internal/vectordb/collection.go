@@ -9,7 +9,26 @@ import (
 
 const vectorDimensions = 768
 
-// EnsureCollection creates collection if it doesn't exist
+// KindComment marks points that embed an issue comment rather than the issue itself
+const KindComment = "comment"
+
+// payloadIndexes are the payload fields indexed for filtering
+var payloadIndexes = []struct {
+	field     string
+	fieldType qdrant.FieldType
+}{
+	{"org", qdrant.FieldType_FieldTypeKeyword},
+	{"repo", qdrant.FieldType_FieldTypeKeyword},
+	{"state", qdrant.FieldType_FieldTypeKeyword},
+	{"number", qdrant.FieldType_FieldTypeInteger},
+	{"labels", qdrant.FieldType_FieldTypeKeyword},
+	{"kind", qdrant.FieldType_FieldTypeKeyword},
+	{"cluster_id", qdrant.FieldType_FieldTypeKeyword},
+	{"created_at", qdrant.FieldType_FieldTypeDatetime},
+}
+
+// EnsureCollection creates collection if it doesn't exist, and adds any
+// payload index missing from an existing one
 func (c *Client) EnsureCollection(ctx context.Context, name string) error {
 	// Check if collection exists
 	exists, err := c.qdrant.CollectionExists(ctx, name)
@@ -17,35 +36,35 @@ func (c *Client) EnsureCollection(ctx context.Context, name string) error {
 		return fmt.Errorf("failed to check collection: %w", err)
 	}
 
+	indexed := map[string]bool{}
 	if exists {
-		return nil
-	}
-
-	// Create collection
-	err = c.qdrant.CreateCollection(ctx, &qdrant.CreateCollection{
-		CollectionName: name,
-		VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
-			Size:     vectorDimensions,
-			Distance: qdrant.Distance_Cosine,
-		}),
-	})
-	if err != nil {
-		return fmt.Errorf("failed to create collection: %w", err)
-	}
-
-	// Create payload indexes for filtering
-	indexes := []struct {
-		field     string
-		fieldType qdrant.FieldType
-	}{
-		{"org", qdrant.FieldType_FieldTypeKeyword},
-		{"repo", qdrant.FieldType_FieldTypeKeyword},
-		{"state", qdrant.FieldType_FieldTypeKeyword},
-		{"number", qdrant.FieldType_FieldTypeInteger},
-		{"labels", qdrant.FieldType_FieldTypeKeyword},
+		info, err := c.qdrant.GetCollectionInfo(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to get collection info: %w", err)
+		}
+		for field := range info.GetPayloadSchema() {
+			indexed[field] = true
+		}
+	} else {
+		// Create collection
+		err = c.qdrant.CreateCollection(ctx, &qdrant.CreateCollection{
+			CollectionName: name,
+			VectorsConfig: qdrant.NewVectorsConfig(&qdrant.VectorParams{
+				Size:     vectorDimensions,
+				Distance: qdrant.Distance_Cosine,
+			}),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create collection: %w", err)
+		}
 	}
 
-	for _, idx := range indexes {
+	// Create payload indexes for filtering; collections created by older
+	// versions get the indexes added since
+	for _, idx := range payloadIndexes {
+		if indexed[idx.field] {
+			continue
+		}
 		_, err = c.qdrant.CreateFieldIndex(ctx, &qdrant.CreateFieldIndexCollection{
 			CollectionName: name,
 			FieldName:      idx.field,
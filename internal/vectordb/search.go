@@ -8,12 +8,15 @@ import (
 
 	"github.com/Kavirubc/gh-simili/pkg/models"
 	"github.com/qdrant/go-client/qdrant"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // SearchResult contains a search result with score
 type SearchResult struct {
-	Issue models.Issue
-	Score float64
+	Issue    models.Issue
+	Score    float64
+	Relation string // set by re-ranking: "same_root_cause" or "related"
+	Reason   string // short justification from re-ranking
 }
 
 // Search finds similar issues in a collection
@@ -51,6 +54,7 @@ func (c *Client) Search(ctx context.Context, collection string, vector []float32
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Score > results[j].Score
 	})
+	results = dedupeByIssue(results)
 
 	// Trim to requested limit
 	if len(results) > limit {
@@ -94,6 +98,7 @@ func (c *Client) SearchFiltered(ctx context.Context, collection string, vector [
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Score > results[j].Score
 	})
+	results = dedupeByIssue(results)
 
 	if len(results) > limit {
 		results = results[:limit]
@@ -102,6 +107,22 @@ func (c *Client) SearchFiltered(ctx context.Context, collection string, vector [
 	return results, nil
 }
 
+// dedupeByIssue keeps the best scoring point per issue, collapsing
+// comment points into their parent issue (results must be sorted)
+func dedupeByIssue(results []SearchResult) []SearchResult {
+	seen := make(map[string]bool, len(results))
+	deduped := results[:0]
+	for _, r := range results {
+		key := r.Issue.UUID()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, r)
+	}
+	return deduped
+}
+
 // payloadToIssue converts Qdrant payload to Issue
 func payloadToIssue(payload map[string]*qdrant.Value) models.Issue {
 	issue := models.Issue{}
@@ -121,12 +142,21 @@ func payloadToIssue(payload map[string]*qdrant.Value) models.Issue {
 	if v := payload["state"]; v != nil {
 		issue.State = v.GetStringValue()
 	}
+	if v := payload["state_reason"]; v != nil {
+		issue.StateReason = v.GetStringValue()
+	}
 	if v := payload["author"]; v != nil {
 		issue.Author = v.GetStringValue()
 	}
 	if v := payload["url"]; v != nil {
 		issue.URL = v.GetStringValue()
 	}
+	if v := payload["reactions"]; v != nil {
+		issue.Reactions = int(v.GetIntegerValue())
+	}
+	if v := payload["cluster_id"]; v != nil {
+		issue.ClusterID = v.GetStringValue()
+	}
 	if v := payload["created_at"]; v != nil {
 		issue.CreatedAt, _ = time.Parse(time.RFC3339, v.GetStringValue())
 	}
@@ -143,3 +173,35 @@ func payloadToIssue(payload map[string]*qdrant.Value) models.Issue {
 
 	return issue
 }
+
+// SearchBefore finds issues created before a point in time, reproducing what
+// the index contained at that moment. Scores are returned unweighted.
+func (c *Client) SearchBefore(ctx context.Context, collection string, vector []float32, limit int, before time.Time) ([]SearchResult, error) {
+	points, err := c.qdrant.Query(ctx, &qdrant.QueryPoints{
+		CollectionName: collection,
+		Query:          qdrant.NewQuery(vector...),
+		Limit:          qdrant.PtrOf(uint64(limit)),
+		WithPayload:    qdrant.NewWithPayload(true),
+		Filter: &qdrant.Filter{
+			Must: []*qdrant.Condition{
+				qdrant.NewDatetimeRange("created_at", &qdrant.DatetimeRange{
+					Lt: timestamppb.New(before),
+				}),
+			},
+			MustNot: []*qdrant.Condition{qdrant.NewMatchKeyword("kind", KindComment)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("search before %s failed: %w", before.Format(time.RFC3339), err)
+	}
+
+	results := make([]SearchResult, 0, len(points))
+	for _, point := range points {
+		results = append(results, SearchResult{
+			Issue: payloadToIssue(point.Payload),
+			Score: float64(point.Score),
+		})
+	}
+
+	return results, nil
+}
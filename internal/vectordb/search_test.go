@@ -0,0 +1,34 @@
+package vectordb
+
+import (
+	"testing"
+
+	"github.com/Kavirubc/gh-simili/pkg/models"
+)
+
+func TestDedupeByIssue(t *testing.T) {
+	a := models.Issue{Org: "org", Repo: "repo", Number: 1}
+	b := models.Issue{Org: "org", Repo: "repo", Number: 2}
+	other := models.Issue{Org: "org", Repo: "other", Number: 1}
+
+	// A comment point carries its parent issue, so it collapses into it
+	results := []SearchResult{
+		{Issue: a, Score: 0.95},
+		{Issue: b, Score: 0.9},
+		{Issue: a, Score: 0.85},
+		{Issue: other, Score: 0.8},
+		{Issue: b, Score: 0.7},
+	}
+
+	got := dedupeByIssue(results)
+
+	want := []float64{0.95, 0.9, 0.8}
+	if len(got) != len(want) {
+		t.Fatalf("dedupeByIssue() returned %d results, want %d", len(got), len(want))
+	}
+	for i, r := range got {
+		if r.Score != want[i] {
+			t.Errorf("dedupeByIssue()[%d].Score = %v, want %v", i, r.Score, want[i])
+		}
+	}
+}
@@ -44,6 +44,69 @@ func (c *Client) UpsertBatch(ctx context.Context, collection string, issues []*m
 	return nil
 }
 
+// UpsertComments inserts or updates comment points linked to an issue
+func (c *Client) UpsertComments(ctx context.Context, collection string, issue *models.Issue, comments []models.Comment, vectors [][]float32) error {
+	if len(comments) != len(vectors) {
+		return fmt.Errorf("comments and vectors length mismatch")
+	}
+	if len(comments) == 0 {
+		return nil
+	}
+
+	points := make([]*qdrant.PointStruct, len(comments))
+	for i, comment := range comments {
+		points[i] = commentToPoint(issue, comment, vectors[i])
+	}
+
+	_, err := c.qdrant.Upsert(ctx, &qdrant.UpsertPoints{
+		CollectionName: collection,
+		Points:         points,
+	})
+	if err != nil {
+		return fmt.Errorf("comment upsert failed: %w", err)
+	}
+	return nil
+}
+
+// DeleteIssueComments removes all comment points linked to an issue
+func (c *Client) DeleteIssueComments(ctx context.Context, collection, org, repo string, number int) error {
+	return c.deleteByFilter(ctx, collection, &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", org),
+			qdrant.NewMatchKeyword("repo", repo),
+			qdrant.NewMatchInt("number", int64(number)),
+			qdrant.NewMatchKeyword("kind", KindComment),
+		},
+	})
+}
+
+// DeleteIssuePoints removes an issue and every point linked to it
+func (c *Client) DeleteIssuePoints(ctx context.Context, collection, org, repo string, number int) error {
+	return c.deleteByFilter(ctx, collection, &qdrant.Filter{
+		Must: []*qdrant.Condition{
+			qdrant.NewMatchKeyword("org", org),
+			qdrant.NewMatchKeyword("repo", repo),
+			qdrant.NewMatchInt("number", int64(number)),
+		},
+	})
+}
+
+// deleteByFilter removes all points matching a filter
+func (c *Client) deleteByFilter(ctx context.Context, collection string, filter *qdrant.Filter) error {
+	_, err := c.qdrant.Delete(ctx, &qdrant.DeletePoints{
+		CollectionName: collection,
+		Points: &qdrant.PointsSelector{
+			PointsSelectorOneOf: &qdrant.PointsSelector_Filter{
+				Filter: filter,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("filtered delete failed: %w", err)
+	}
+	return nil
+}
+
 // Delete removes a point by ID
 func (c *Client) Delete(ctx context.Context, collection string, id string) error {
 	_, err := c.qdrant.Delete(ctx, &qdrant.DeletePoints{
@@ -92,7 +155,7 @@ func issueToPoint(issue *models.Issue, vector []float32) *qdrant.PointStruct {
 		labelValues[i] = qdrant.NewValueString(label)
 	}
 
-	return &qdrant.PointStruct{
+	point := &qdrant.PointStruct{
 		Id:      qdrant.NewIDUUID(issue.UUID()),
 		Vectors: qdrant.NewVectors(vector...),
 		Payload: map[string]*qdrant.Value{
@@ -103,6 +166,7 @@ func issueToPoint(issue *models.Issue, vector []float32) *qdrant.PointStruct {
 			"state":      qdrant.NewValueString(issue.State),
 			"author":     qdrant.NewValueString(issue.Author),
 			"url":        qdrant.NewValueString(issue.URL),
+			"reactions":  qdrant.NewValueInt(int64(issue.Reactions)),
 			"body_hash":  qdrant.NewValueString(issue.BodyHash()),
 			"created_at": qdrant.NewValueString(issue.CreatedAt.Format(time.RFC3339)),
 			"updated_at": qdrant.NewValueString(issue.UpdatedAt.Format(time.RFC3339)),
@@ -113,4 +177,24 @@ func issueToPoint(issue *models.Issue, vector []float32) *qdrant.PointStruct {
 			},
 		},
 	}
+
+	if issue.ClusterID != "" {
+		point.Payload["cluster_id"] = qdrant.NewValueString(issue.ClusterID)
+	}
+	if issue.StateReason != "" {
+		point.Payload["state_reason"] = qdrant.NewValueString(issue.StateReason)
+	}
+
+	return point
+}
+
+// commentToPoint converts an issue comment to a Qdrant point that carries
+// the parent issue's payload so search results resolve to the issue
+func commentToPoint(issue *models.Issue, comment models.Comment, vector []float32) *qdrant.PointStruct {
+	point := issueToPoint(issue, vector)
+	point.Id = qdrant.NewIDUUID(models.CommentUUID(issue.Org, issue.Repo, issue.Number, comment.ID))
+	point.Payload["kind"] = qdrant.NewValueString(KindComment)
+	point.Payload["comment_id"] = qdrant.NewValueInt(int64(comment.ID))
+	point.Payload["comment_author"] = qdrant.NewValueString(comment.Author)
+	return point
 }
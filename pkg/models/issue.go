@@ -11,17 +11,31 @@ import (
 
 // Issue represents a GitHub issue with its metadata
 type Issue struct {
-	Org       string    `json:"org"`
-	Repo      string    `json:"repo"`
-	Number    int       `json:"number"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	State     string    `json:"state"` // "open" or "closed"
-	Labels    []string  `json:"labels"`
-	Author    string    `json:"author"`
-	URL       string    `json:"url"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Org               string    `json:"org"`
+	Repo              string    `json:"repo"`
+	Number            int       `json:"number"`
+	Title             string    `json:"title"`
+	Body              string    `json:"body"`
+	State             string    `json:"state"`                  // "open" or "closed"
+	StateReason       string    `json:"state_reason,omitempty"` // "completed", "not_planned" or "duplicate" when closed
+	Labels            []string  `json:"labels"`
+	Author            string    `json:"author"`
+	AuthorAssociation string    `json:"author_association,omitempty"` // e.g. "MEMBER" or "FIRST_TIME_CONTRIBUTOR"
+	Type              string    `json:"type,omitempty"`               // GitHub issue type, e.g. "Bug"
+	URL               string    `json:"url"`
+	Reactions         int       `json:"reactions,omitempty"`
+	ClusterID         string    `json:"cluster_id,omitempty"` // duplicate cluster this issue belongs to
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Comment represents an issue comment selected for indexing
+type Comment struct {
+	ID         int    `json:"id"`
+	Author     string `json:"author"`
+	Body       string `json:"body"`
+	Reactions  int    `json:"reactions"`
+	Maintainer bool   `json:"maintainer"`
 }
 
 // FullRepo returns the full repository name (org/repo)
@@ -45,3 +59,9 @@ func IssueUUID(org, repo string, number int) string {
 	data := fmt.Sprintf("%s/%s#%d", org, repo, number)
 	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(data)).String()
 }
+
+// CommentUUID generates a deterministic UUID for a comment point of an issue
+func CommentUUID(org, repo string, number, commentID int) string {
+	data := fmt.Sprintf("%s/%s#%d/comment/%d", org, repo, number, commentID)
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(data)).String()
+}
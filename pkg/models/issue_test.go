@@ -33,6 +33,23 @@ func TestIssueUUID(t *testing.T) {
 	}
 }
 
+func TestCommentUUID(t *testing.T) {
+	id := CommentUUID("myorg", "myrepo", 123, 42)
+
+	if id != CommentUUID("myorg", "myrepo", 123, 42) {
+		t.Errorf("CommentUUID not deterministic")
+	}
+	if len(id) != 36 {
+		t.Errorf("CommentUUID invalid length: %d", len(id))
+	}
+	if id == IssueUUID("myorg", "myrepo", 123) {
+		t.Errorf("CommentUUID collides with the issue UUID")
+	}
+	if id == CommentUUID("myorg", "myrepo", 124, 42) {
+		t.Errorf("CommentUUID ignores the issue number")
+	}
+}
+
 func TestIssue_FullRepo(t *testing.T) {
 	issue := &Issue{
 		Org:  "myorg",